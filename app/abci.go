@@ -1,15 +1,15 @@
 package app
 
 import (
-	"bytes"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/celestiaorg/celestia-app/x/payment/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
 	abci "github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/pkg/consts"
 	core "github.com/tendermint/tendermint/proto/tendermint/types"
 	coretypes "github.com/tendermint/tendermint/types"
 )
@@ -18,93 +18,182 @@ import (
 // performing basic validation for the incoming txs, and by cleanly separating
 // share messages from transactions
 func (app *App) PreprocessTxs(txs abci.RequestPreprocessTxs) abci.ResponsePreprocessTxs {
-	squareSize := app.SquareSize()
-	shareCounter := uint64(0)
+	candidates, rejections := app.parseTxs(txs.Txs)
+
+	// select the square size, and fill the square with the messages that pay
+	// the highest fee per share, leaving the rest in the mempool for a later
+	// block. Only the txs and messages are counted: celestia-core does not add
+	// intermediate state roots to the block, and it adds the pending evidence
+	// after PreprocessTxs returns, without passing it to the app.
+	square := app.selectSquare(candidates)
+	app.reportRejections(append(rejections, square.rejections...))
+
 	var shareMsgs []*core.Message
 	var processedTxs [][]byte
-	for _, rawTx := range txs.Txs {
+	for _, btx := range square.txs {
+		if btx.isPayForMessage() {
+			shareMsgs = append(shareMsgs, btx.msgs...)
+		}
+		processedTxs = append(processedTxs, btx.raw)
+	}
+
+	// messages are laid out in the square in the order that they are returned
+	sort.SliceStable(shareMsgs, func(i, j int) bool {
+		return messageLess(shareMsgs[i], shareMsgs[j])
+	})
+
+	return abci.ResponsePreprocessTxs{
+		Txs:      processedTxs,
+		Messages: &core.Messages{MessagesList: shareMsgs},
+	}
+}
+
+// parseTxs decodes the provided txs and performs basic validation on the txs
+// that contain MsgWirePayForMessages, including checking them against the
+// payment module's params. Txs that fail to decode or validate are returned as
+// rejections instead.
+func (app *App) parseTxs(rawTxs [][]byte) ([]*blockTx, []rejection) {
+	var (
+		parsed     []*blockTx
+		rejections []rejection
+	)
+	params := app.PaymentKeeper.GetParams(app.NewContext(true, core.Header{}))
+	for _, rawTx := range rawTxs {
 		// decode the Tx
 		tx, err := app.txConfig.TxDecoder()(rawTx)
 		if err != nil {
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_DECODE_FAILURE, err))
 			continue
 		}
 
 		authTx, ok := tx.(signing.Tx)
 		if !ok {
+			err := fmt.Errorf("unexpected tx type %T", tx)
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_DECODE_FAILURE, err))
 			continue
 		}
 
-		// don't process the tx if the transaction doesn't contain a
-		//  MsgPayForMessage sdk.Msg
-		if !hasWirePayForMessage(authTx) {
-			processedTxs = append(processedTxs, rawTx)
+		signers := make([]string, len(authTx.GetSigners()))
+		for i, signer := range authTx.GetSigners() {
+			signers[i] = signer.String()
+		}
+
+		// a MsgPayForMessage is only executed in a tx that was malleated by
+		// the block producer, which the raw txs never are
+		if hasPayForMessage(authTx) {
+			err := errors.New("MsgPayForMessage is not in a malleated tx")
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_UNWRAPPED_PFM, err))
 			continue
 		}
 
-		// only support transactions that contain a single sdk.Msg
-		if len(authTx.GetMsgs()) != 1 {
+		// don't process the tx if the transaction doesn't contain a
+		//  MsgPayForMessage sdk.Msg
+		if !hasWirePayForMessage(authTx) {
+			parsed = append(parsed, &blockTx{raw: rawTx, signers: signers})
 			continue
 		}
 
-		msg := authTx.GetMsgs()[0]
-		wireMsg, ok := msg.(*types.MsgWirePayForMessage)
-		if !ok {
+		// a tx can contain multiple MsgWirePayForMessages, but they can't be
+		// mixed with other sdk.Msgs
+		wireMsgs, err := wirePayForMessages(authTx)
+		if err != nil {
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_MULTIPLE_MSGS, err))
 			continue
 		}
 
 		// run basic validation on the transaction
 		err = authTx.ValidateBasic()
 		if err != nil {
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_INVALID_BASIC, err))
 			continue
 		}
 
-		// parse wire message and create a single message
-		coreMsg, unsignedPFM, sig, err := types.ProcessWirePayForMessage(wireMsg, app.SquareSize())
+		err = validateWirePayForMessages(params, wireMsgs)
 		if err != nil {
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_PARAMS_VIOLATION, err))
 			continue
 		}
 
-		// create the signed PayForMessage using the fees, gas limit, and sequence from
-		// the original transaction, along with the appropriate signature.
-		signedTx, err := types.BuildPayForMessageTxFromWireTx(authTx, app.txConfig.NewTxBuilder(), sig, unsignedPFM)
-		if err != nil {
-			app.Logger().Error("failure to create signed PayForMessage", err)
+		// the tx is malleated once the square size is known, which is only
+		// possible for a square size that every message commits to
+		parentHash := sha256.Sum256(rawTx)
+		btx := &blockTx{
+			raw:        rawTx,
+			signers:    signers,
+			authTx:     authTx,
+			wireMsgs:   wireMsgs,
+			parentHash: parentHash[:],
+		}
+		if _, ok := btx.anyCommittedSquareSize(); !ok {
+			err := errors.New("messages do not commit to a common square size")
+			rejections = append(rejections, newRejection(rawTx, types.RejectionReason_REJECTION_REASON_MISSING_COMMITMENT, err))
 			continue
 		}
+		btx.feePerShare = feePerShare(authTx.GetFee(), wireMsgs)
 
-		// increment the share counter by the number of shares taken by the message
-		sharesTaken := uint64(len(coreMsg.Data) / types.ShareSize)
-		shareCounter += sharesTaken
+		parsed = append(parsed, btx)
+	}
+	return parsed, rejections
+}
 
-		// if there are too many shares stop processing and return the transactions
-		if shareCounter > squareSize*squareSize {
-			break
-		}
+// malleate replaces the raw bytes of a tx containing MsgWirePayForMessages
+// with the malleated tx containing the MsgPayForMessages for the provided
+// square size, and sets the messages that it pays for.
+func (app *App) malleate(btx *blockTx, squareSize uint64) error {
+	// parse the wire messages and create a message for each of them
+	coreMsgs, unsignedPFMs, sig, err := types.ProcessWirePayForMessages(btx.wireMsgs, squareSize)
+	if err != nil {
+		return err
+	}
 
-		rawProcessedTx, err := app.txConfig.TxEncoder()(signedTx)
-		if err != nil {
-			continue
-		}
+	// create the signed PayForMessages using the fees, fee granter, gas limit, and sequence from
+	// the original transaction, along with the appropriate signature.
+	signedTx, err := types.BuildPayForMessageTxFromWireTx(btx.authTx, app.txConfig.NewTxBuilder(), sig, unsignedPFMs...)
+	if err != nil {
+		app.Logger().Error("failure to create signed PayForMessage", err)
+		return err
+	}
 
-		parentHash := sha256.Sum256(rawTx)
-		wrappedTx, err := coretypes.WrapMalleatedTx(parentHash[:], rawProcessedTx)
-		if err != nil {
-			app.Logger().Error("failure to wrap child transaction with parent hash", "Error:", err)
-		}
+	rawProcessedTx, err := app.txConfig.TxEncoder()(signedTx)
+	if err != nil {
+		return err
+	}
 
-		shareMsgs = append(shareMsgs, coreMsg)
-		processedTxs = append(processedTxs, wrappedTx)
+	wrappedTx, err := coretypes.WrapMalleatedTx(btx.parentHash, rawProcessedTx)
+	if err != nil {
+		app.Logger().Error("failure to wrap child transaction with parent hash", "Error:", err)
+		return err
 	}
 
-	// sort messages lexigraphically
-	sort.Slice(shareMsgs, func(i, j int) bool {
-		return bytes.Compare(shareMsgs[i].NamespaceId, shareMsgs[j].NamespaceId) < 0
-	})
+	btx.raw = wrappedTx
+	btx.msgs = coreMsgs
+	return nil
+}
 
-	return abci.ResponsePreprocessTxs{
-		Txs:      processedTxs,
-		Messages: &core.Messages{MessagesList: shareMsgs},
+// wirePayForMessages returns the MsgWirePayForMessages of a tx, or an error if
+// the tx also contains other sdk.Msgs
+func wirePayForMessages(tx sdk.Tx) ([]*types.MsgWirePayForMessage, error) {
+	msgs := tx.GetMsgs()
+	wireMsgs := make([]*types.MsgWirePayForMessage, len(msgs))
+	for i, msg := range msgs {
+		wireMsg, ok := msg.(*types.MsgWirePayForMessage)
+		if !ok {
+			return nil, fmt.Errorf("unexpected msg type %s in a tx with MsgWirePayForMessages", sdk.MsgTypeURL(msg))
+		}
+		wireMsgs[i] = wireMsg
+	}
+	return wireMsgs, nil
+}
+
+// validateWirePayForMessages checks each of the MsgWirePayForMessages against
+// the payment module's params
+func validateWirePayForMessages(params types.Params, wireMsgs []*types.MsgWirePayForMessage) error {
+	for _, wireMsg := range wireMsgs {
+		if err := params.ValidateWirePayForMessage(wireMsg); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 func hasWirePayForMessage(tx sdk.Tx) bool {
@@ -117,8 +206,134 @@ func hasWirePayForMessage(tx sdk.Tx) bool {
 	return false
 }
 
-// SquareSize returns the current square size. Currently, the square size is
-// hardcoded. todo(evan): don't hardcode the square size
-func (app *App) SquareSize() uint64 {
-	return consts.MaxSquareSize
+func hasPayForMessage(tx sdk.Tx) bool {
+	for _, msg := range tx.GetMsgs() {
+		if sdk.MsgTypeURL(msg) == types.URLMsgPayforMessage {
+			return true
+		}
+	}
+	return false
+}
+
+// proposedSquare is the block data that PreprocessTxs proposes for a square
+// size
+type proposedSquare struct {
+	squareSize uint64
+	// txs are the selected txs, with the PayForMessage txs malleated for the
+	// square size
+	txs        []*blockTx
+	rejections []rejection
+	// shares is the number of shares taken up by the selected txs and their
+	// messages
+	shares uint64
+}
+
+// selectSquare selects the square size and the txs of the proposed block.
+// celestia-core builds the smallest square that fits the block data, and the
+// app can't pass it the square size that the PayForMessages were malleated for,
+// so the block data must take up enough shares for celestia-core to build a
+// square of exactly that size. For each square size allowed by the payment
+// module's params, the txs that commit to it are packed into the square, and
+// the square sizes that celestia-core would build a different square for are
+// skipped. Of the remaining ones, the square size that includes the most txs is
+// selected, preferring smaller squares. A tx that only commits to other square
+// sizes is left out, instead of growing the square. The square sizes are tried
+// from the smallest one, so no larger square size is tried once one fits all
+// the txs.
+func (app *App) selectSquare(txs []*blockTx) *proposedSquare {
+	// PreprocessTxs is not called with a context, so the params are read from
+	// the latest committed state
+	params := app.PaymentKeeper.GetParams(app.NewContext(true, core.Header{}))
+
+	var selected *proposedSquare
+	for k := params.MinSquareSize; k <= params.MaxSquareSize; k *= 2 {
+		square := app.buildSquare(txs, k, true)
+		if types.SquareSizeForShares(square.shares) != k {
+			continue
+		}
+		if selected == nil || len(square.txs) > len(selected.txs) {
+			selected = square
+		}
+		if len(selected.txs) == len(txs) {
+			break
+		}
+	}
+	if selected != nil {
+		return selected
+	}
+
+	// the min square size is too large for the pending txs, so no messages
+	// can be paid for in this block. The txs without messages are still
+	// included, and celestia-core builds a square smaller than the min square
+	// size for them.
+	selected = app.buildSquare(txs, params.MaxSquareSize, false)
+	selected.squareSize = types.SquareSizeForShares(selected.shares)
+	return selected
+}
+
+// buildSquare packs the txs into a square of the provided size. If
+// payForMessages is true, the PayForMessage txs are malleated for the square
+// size, and those that don't commit to it are rejected. Otherwise, they are
+// left out without being rejected. As the txs of a signer must be executed in
+// the order of their sequence, the later txs of a signer are left out as well
+// once one of its txs is.
+func (app *App) buildSquare(txs []*blockTx, squareSize uint64, payForMessages bool) *proposedSquare {
+	square := &proposedSquare{squareSize: squareSize}
+
+	leftOut := make(map[string]bool)
+	isLeftOut := func(btx *blockTx) bool {
+		for _, signer := range btx.signers {
+			if leftOut[signer] {
+				return true
+			}
+		}
+		return false
+	}
+	leaveOut := func(btx *blockTx) {
+		for _, signer := range btx.signers {
+			leftOut[signer] = true
+		}
+	}
+
+	candidates := make([]*blockTx, 0, len(txs))
+	for _, btx := range txs {
+		if isLeftOut(btx) {
+			continue
+		}
+		if !btx.isPayForMessage() {
+			candidates = append(candidates, btx)
+			continue
+		}
+		if !payForMessages {
+			leaveOut(btx)
+			continue
+		}
+		if !btx.commitsTo(squareSize) {
+			leaveOut(btx)
+			square.rejections = append(square.rejections, rejection{
+				txHash: btx.parentHash,
+				reason: types.RejectionReason_REJECTION_REASON_MISSING_COMMITMENT,
+				err:    fmt.Errorf("messages do not commit to square size %d", squareSize),
+			})
+			continue
+		}
+		// the tx is malleated for every square size that is tried, so a copy
+		// is malleated instead
+		malleated := *btx
+		if err := app.malleate(&malleated, squareSize); err != nil {
+			leaveOut(btx)
+			square.rejections = append(square.rejections, rejection{
+				txHash: btx.parentHash,
+				reason: types.RejectionReason_REJECTION_REASON_MALLEATION_FAILURE,
+				err:    err,
+			})
+			continue
+		}
+		candidates = append(candidates, &malleated)
+	}
+
+	counter := newShareCounter(squareSize)
+	square.txs = prioritize(candidates, counter)
+	square.shares = counter.size()
+	return square
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -18,6 +19,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/simapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/spf13/cast"
@@ -26,8 +29,8 @@ import (
 	"github.com/tendermint/spm/cosmoscmd"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
-	"github.com/tendermint/tendermint/pkg/consts"
 	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 )
 
@@ -95,6 +98,252 @@ func TestPreprocessTxs(t *testing.T) {
 	}
 }
 
+// proposedSquareSize returns the size of the square that celestia-core builds
+// from the block data that PreprocessTxs proposes for the provided txs
+func proposedSquareSize(app *App, txs [][]byte) uint64 {
+	parsed, _ := app.parseTxs(txs)
+	return app.selectSquare(parsed).squareSize
+}
+
+func TestSquareSize(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	testApp := setupApp(t, info.GetPubKey())
+
+	ns := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	smallTx := generateRawTx(t, testApp.txConfig, ns, []byte{1}, kb)
+	largeTx := generateRawTx(t, testApp.txConfig, ns, bytes.Repeat([]byte{1}, 100*types.ShareSize), kb)
+
+	type test struct {
+		name     string
+		txs      [][]byte
+		expected uint64
+	}
+	tests := []test{
+		{
+			name:     "no txs",
+			txs:      nil,
+			expected: 1,
+		},
+		{
+			name:     "single small message",
+			txs:      [][]byte{smallTx},
+			expected: 2,
+		},
+		{
+			name:     "large message",
+			txs:      [][]byte{smallTx, largeTx},
+			expected: 16,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, proposedSquareSize(testApp, tt.txs), tt.name)
+	}
+}
+
+// TestPreprocessTxsCoreSquareSize checks that the txs are malleated for the
+// size of the square that celestia-core builds from the proposed block data
+func TestPreprocessTxsCoreSquareSize(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	testApp := setupApp(t, info.GetPubKey())
+
+	ns := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	validTx := generateRawTx(t, testApp.txConfig, ns, []byte{1}, kb)
+	// the message is too small for celestia-core to build an 8x8 square
+	onlyLargerTx := buildRawTx(t, testApp.txConfig, generateSignedWirePayForMessage(t, ns, []byte{1}, kb, 8))
+
+	type test struct {
+		name        string
+		txs         [][]byte
+		expectedTxs int
+	}
+	tests := []test{
+		{"only commits to a larger square", [][]byte{onlyLargerTx}, 0},
+		{"does not grow the square", [][]byte{validTx, onlyLargerTx}, 1},
+	}
+
+	for _, tt := range tests {
+		res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: tt.txs})
+		require.Len(t, res.Txs, tt.expectedTxs, tt.name)
+
+		data := coretypes.Data{Messages: coretypes.MessagesFromProto(res.Messages)}
+		for _, tx := range res.Txs {
+			data.Txs = append(data.Txs, tx)
+		}
+		shares, _ := data.ComputeShares()
+		squareSize := proposedSquareSize(testApp, tt.txs)
+		assert.Equal(t, int(squareSize*squareSize), len(shares), tt.name)
+		assert.Less(t, squareSize, uint64(8), tt.name)
+
+		// validators derive the same square size from the block data
+		assert.NoError(t, testApp.ProcessProposal(&core.Data{Txs: res.Txs, Messages: *res.Messages}), tt.name)
+	}
+
+	ctx := sdk.WrapSDKContext(testApp.NewContext(true, core.Header{}))
+	txHash := fmt.Sprintf("%X", sha256.Sum256(onlyLargerTx))
+	resp, err := testApp.PaymentKeeper.Rejection(ctx, &types.QueryRejectionRequest{TxHash: txHash})
+	require.NoError(t, err)
+	assert.Equal(t, types.RejectionReason_REJECTION_REASON_MISSING_COMMITMENT, resp.Rejection.Reason)
+}
+
+func TestPreprocessTxsMultipleMessages(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	testApp := setupApp(t, info.GetPubKey())
+
+	firstNS := []byte{2, 2, 2, 2, 2, 2, 2, 2}
+	firstMessage := bytes.Repeat([]byte{2}, 512)
+	secondNS := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	secondMessage := bytes.Repeat([]byte{1}, 256)
+
+	// both msgs must commit to the same square sizes
+	sizes := types.AllSquareSizes(len(firstMessage))
+	first, err := types.NewWirePayForMessage(firstNS, firstMessage, sizes...)
+	require.NoError(t, err)
+	second, err := types.NewWirePayForMessage(secondNS, secondMessage, sizes...)
+	require.NoError(t, err)
+
+	signer := generateKeyringSigner(t, "test")
+	err = types.SignWirePayForMessages(
+		signer,
+		[]*types.MsgWirePayForMessage{first, second},
+		types.SetGasLimit(10000),
+		types.SetFeeAmount(sdk.NewCoins(sdk.NewCoin("token", sdk.NewInt(1000)))),
+	)
+	require.NoError(t, err)
+	rawTx := buildRawTx(t, testApp.txConfig, first, second)
+
+	res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: [][]byte{rawTx}})
+	require.Len(t, res.Txs, 1)
+	assert.Equal(t, []*core.Message{
+		{NamespaceId: secondNS, Data: secondMessage},
+		{NamespaceId: firstNS, Data: firstMessage},
+	}, res.Messages.MessagesList)
+
+	// the malleated tx contains a MsgPayForMessage for each message, in the
+	// order of the original tx
+	_, childTx, isMalleated := coretypes.UnwrapMalleatedTx(res.Txs[0])
+	require.True(t, isMalleated)
+	tx, err := testApp.txConfig.TxDecoder()(childTx)
+	require.NoError(t, err)
+	require.Len(t, tx.GetMsgs(), 2)
+	assert.Equal(t, firstNS, tx.GetMsgs()[0].(*types.MsgPayForMessage).MessageNamespaceId)
+	assert.Equal(t, secondNS, tx.GetMsgs()[1].(*types.MsgPayForMessage).MessageNamespaceId)
+
+	// the signature included in the wire msgs is valid for the malleated tx
+	sigTx := tx.(authsigning.SigVerifiableTx)
+	sigs, err := sigTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	signBytes, err := testApp.txConfig.SignModeHandler().GetSignBytes(
+		signing.SignMode_SIGN_MODE_DIRECT,
+		authsigning.SignerData{ChainID: testChainID, AccountNumber: 0, Sequence: sigs[0].Sequence},
+		sigTx,
+	)
+	require.NoError(t, err)
+	sig := sigs[0].Data.(*signing.SingleSignatureData).Signature
+	assert.True(t, sigs[0].PubKey.VerifySignature(signBytes, sig))
+
+	// validators accept the proposed block
+	data := &core.Data{Txs: res.Txs, Messages: *res.Messages}
+	assert.NoError(t, testApp.ProcessProposal(data))
+}
+
+func TestPreprocessTxsRejections(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	testApp := setupApp(t, info.GetPubKey())
+
+	ns := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	// the message takes up enough shares for the min square size of 4
+	validTx := generateRawTx(t, testApp.txConfig, ns, make([]byte, 4*types.ShareSize), kb)
+
+	undecodableTx := []byte("not a tx")
+
+	// txs without signatures fail basic validation
+	unsignedBuilder := testApp.txConfig.NewTxBuilder()
+	err = unsignedBuilder.SetMsgs(generateSignedWirePayForMessage(t, ns, []byte{1}, kb, types.AllSquareSizes(1)...))
+	require.NoError(t, err)
+	invalidTx, err := testApp.txConfig.TxEncoder()(unsignedBuilder.GetTx())
+	require.NoError(t, err)
+
+	// only commit to a square size that is smaller than the min square size
+	tooSmallMsg := generateSignedWirePayForMessage(t, ns, []byte{1}, kb, 2)
+	tooSmallTx := buildRawTx(t, testApp.txConfig, tooSmallMsg)
+
+	// MsgWirePayForMessages can't be mixed with other msgs
+	signerAddr := generateKeyringSigner(t, "test").GetSignerInfo().GetAddress()
+	send := banktypes.NewMsgSend(signerAddr, signerAddr, sdk.NewCoins(sdk.NewCoin("token", sdk.NewInt(1))))
+	mixedMsg := generateSignedWirePayForMessage(t, ns, []byte{1}, kb, types.AllSquareSizes(1)...)
+	mixedTx := buildRawTx(t, testApp.txConfig, mixedMsg, send)
+
+	// MsgPayForMessages are only included once malleated by the block producer
+	_, unwrappedMsg, _, err := types.ProcessWirePayForMessage(mixedMsg, types.AllSquareSizes(1)[0])
+	require.NoError(t, err)
+	unwrappedTx := buildRawTx(t, testApp.txConfig, unwrappedMsg)
+
+	// messages larger than the max message bytes param are not included. The
+	// message is padded to a multiple of the share size.
+	params := testApp.PaymentKeeper.GetParams(testApp.NewContext(true, core.Header{}))
+	params.MinSquareSize = 4
+	params.MaxMessageBytes = 4 * types.ShareSize
+	testApp.PaymentKeeper.SetParams(testApp.NewContext(true, core.Header{}), params)
+	tooLargeTx := generateRawTx(t, testApp.txConfig, ns, make([]byte, 4*types.ShareSize+1), kb)
+
+	res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{
+		Txs: [][]byte{validTx, undecodableTx, invalidTx, tooSmallTx, mixedTx, unwrappedTx, tooLargeTx},
+	})
+	assert.Len(t, res.Txs, 1)
+
+	type test struct {
+		name     string
+		rawTx    []byte
+		expected types.RejectionReason
+	}
+	tests := []test{
+		{"undecodable tx", undecodableTx, types.RejectionReason_REJECTION_REASON_DECODE_FAILURE},
+		{"invalid tx", invalidTx, types.RejectionReason_REJECTION_REASON_INVALID_BASIC},
+		{"missing commitment", tooSmallTx, types.RejectionReason_REJECTION_REASON_MISSING_COMMITMENT},
+		{"mixed msgs", mixedTx, types.RejectionReason_REJECTION_REASON_MULTIPLE_MSGS},
+		{"unwrapped MsgPayForMessage", unwrappedTx, types.RejectionReason_REJECTION_REASON_UNWRAPPED_PFM},
+		{"message too large", tooLargeTx, types.RejectionReason_REJECTION_REASON_PARAMS_VIOLATION},
+	}
+
+	ctx := sdk.WrapSDKContext(testApp.NewContext(true, core.Header{}))
+	for _, tt := range tests {
+		// the query should not be sensitive to the case of the hash
+		txHash := fmt.Sprintf("%x", sha256.Sum256(tt.rawTx))
+		resp, err := testApp.PaymentKeeper.Rejection(ctx, &types.QueryRejectionRequest{TxHash: txHash})
+		require.NoError(t, err, tt.name)
+		assert.Equal(t, tt.expected, resp.Rejection.Reason, tt.name)
+		assert.Equal(t, int64(1), resp.Rejection.Height, tt.name)
+		assert.NotEmpty(t, resp.Rejection.Error, tt.name)
+	}
+
+	// included txs are not rejected
+	validHash := fmt.Sprintf("%X", sha256.Sum256(validTx))
+	_, err = testApp.PaymentKeeper.Rejection(ctx, &types.QueryRejectionRequest{TxHash: validHash})
+	assert.Error(t, err)
+}
+
 func setupApp(t *testing.T, pub cryptotypes.PubKey) *App {
 	// var cache sdk.MultiStorePersistentCache
 	// EmptyAppOptions is a stub implementing AppOptions
@@ -129,6 +378,7 @@ func setupApp(t *testing.T, pub cryptotypes.PubKey) *App {
 	// Initialize the chain
 	testApp.InitChain(
 		abci.RequestInitChain{
+			ChainId:       testChainID,
 			Validators:    []abci.ValidatorUpdate{},
 			AppStateBytes: stateBytes,
 		},
@@ -208,9 +458,13 @@ func addGenesisAccount(addr sdk.AccAddress, appState map[string]json.RawMessage,
 }
 
 func generateRawTx(t *testing.T, txConfig client.TxConfig, ns, message []byte, ring keyring.Keyring) (rawTx []byte) {
-	// create a msg
-	msg := generateSignedWirePayForMessage(t, consts.MaxSquareSize, ns, message, ring)
+	// create a msg that commits to every square size
+	msg := generateSignedWirePayForMessage(t, ns, message, ring, types.AllSquareSizes(len(message))...)
+	return buildRawTx(t, txConfig, msg)
+}
 
+// buildRawTx signs and encodes a tx containing the provided msgs
+func buildRawTx(t *testing.T, txConfig client.TxConfig, msgs ...sdk.Msg) (rawTx []byte) {
 	krs := generateKeyringSigner(t, "test")
 	builder := krs.NewTxBuilder()
 
@@ -223,7 +477,7 @@ func generateRawTx(t *testing.T, txConfig client.TxConfig, ns, message []byte, r
 	builder.SetGasLimit(10000)
 	builder.SetTimeoutHeight(99)
 
-	tx, err := krs.BuildSignedTx(builder, msg)
+	tx, err := krs.BuildSignedTx(builder, msgs...)
 	require.NoError(t, err)
 
 	// encode the tx
@@ -233,10 +487,10 @@ func generateRawTx(t *testing.T, txConfig client.TxConfig, ns, message []byte, r
 	return rawTx
 }
 
-func generateSignedWirePayForMessage(t *testing.T, k uint64, ns, message []byte, ring keyring.Keyring) *types.MsgWirePayForMessage {
+func generateSignedWirePayForMessage(t *testing.T, ns, message []byte, ring keyring.Keyring, sizes ...uint64) *types.MsgWirePayForMessage {
 	signer := generateKeyringSigner(t, "test")
 
-	msg, err := types.NewWirePayForMessage(ns, message, k)
+	msg, err := types.NewWirePayForMessage(ns, message, sizes...)
 	if err != nil {
 		t.Error(err)
 	}
@@ -0,0 +1,19 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// chainAnteDecorators runs the provided decorators after the ante handler,
+// which allows for the payment module's decorators to be added after the
+// default SDK ante handler
+func chainAnteDecorators(anteHandler sdk.AnteHandler, decorators ...sdk.AnteDecorator) sdk.AnteHandler {
+	next := sdk.ChainAnteDecorators(decorators...)
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		newCtx, err := anteHandler(ctx, tx, simulate)
+		if err != nil {
+			return newCtx, err
+		}
+		return next(newCtx, tx, simulate)
+	}
+}
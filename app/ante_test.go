@@ -0,0 +1,263 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	paymentante "github.com/celestiaorg/celestia-app/x/payment/ante"
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestWirePayForMessageDecorator(t *testing.T) {
+	signer := generateKeyringSigner(t, "test")
+	type test struct {
+		name string
+		// mutate is applied to the signed msgs before the tx is signed
+		mutate   func(msgs []*types.MsgWirePayForMessage)
+		params   func(*types.Params)
+		expected *sdkerrors.Error
+	}
+	tests := []test{
+		{
+			name:   "valid tx",
+			mutate: func([]*types.MsgWirePayForMessage) {},
+			params: func(*types.Params) {},
+		},
+		{
+			name: "invalid signature for one of the square sizes",
+			mutate: func(msgs []*types.MsgWirePayForMessage) {
+				for _, msg := range msgs {
+					msg.MessageShareCommitment[1].Signature = bytes.Repeat([]byte{1}, 64)
+				}
+			},
+			params:   func(*types.Params) {},
+			expected: sdkerrors.ErrUnauthorized,
+		},
+		{
+			name: "different signatures for the same square size",
+			mutate: func(msgs []*types.MsgWirePayForMessage) {
+				msgs[1].MessageShareCommitment[0].Signature = bytes.Repeat([]byte{1}, 64)
+			},
+			params:   func(*types.Params) {},
+			expected: sdkerrors.ErrInvalidRequest,
+		},
+		{
+			name: "commitments signed with a different sequence",
+			mutate: func(msgs []*types.MsgWirePayForMessage) {
+				other := generateKeyringSigner(t, "test")
+				other.SetSequence(1)
+				require.NoError(t, types.SignWirePayForMessages(other, msgs, wireTxOptions()...))
+			},
+			params:   func(*types.Params) {},
+			expected: sdkerrors.ErrUnauthorized,
+		},
+		{
+			name: "commitments signed with a different account number",
+			mutate: func(msgs []*types.MsgWirePayForMessage) {
+				other := generateKeyringSigner(t, "test")
+				other.SetAccountNumber(1)
+				require.NoError(t, types.SignWirePayForMessages(other, msgs, wireTxOptions()...))
+			},
+			params:   func(*types.Params) {},
+			expected: sdkerrors.ErrUnauthorized,
+		},
+		{
+			name:   "missing a required square size",
+			mutate: func([]*types.MsgWirePayForMessage) {},
+			params: func(p *types.Params) {
+				p.RequiredSquareSizes = []uint64{128}
+			},
+			expected: types.ErrMissingCommitment,
+		},
+		{
+			name:   "message is too large",
+			mutate: func([]*types.MsgWirePayForMessage) {},
+			params: func(p *types.Params) {
+				p.MaxMessageBytes = types.ShareSize
+			},
+			expected: types.ErrMessageTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		testApp := setupApp(t, signer.GetSignerInfo().GetPubKey())
+		// commit the genesis state so that it can be used by CheckTx
+		testApp.Commit()
+
+		checkCtx := testApp.NewContext(true, core.Header{})
+		params := testApp.PaymentKeeper.GetParams(checkCtx)
+		tt.params(&params)
+		testApp.PaymentKeeper.SetParams(checkCtx, params)
+
+		rawTx := buildWireTx(t, testApp.txConfig, signer, tt.mutate,
+			[]byte{1, 2, 3},
+			bytes.Repeat([]byte{2}, 2*types.ShareSize),
+		)
+
+		res := testApp.CheckTx(abci.RequestCheckTx{Tx: rawTx})
+		if tt.expected != nil {
+			assert.Equal(t, tt.expected.Codespace(), res.Codespace, tt.name)
+			assert.Equal(t, tt.expected.ABCICode(), res.Code, tt.name)
+			continue
+		}
+		assert.Equal(t, abci.CodeTypeOK, res.Code, tt.name, res.Log)
+
+		// the same tx is rejected when it is included in a block without
+		// being malleated
+		testApp.BeginBlock(abci.RequestBeginBlock{Header: core.Header{Height: 2, ChainID: testChainID}})
+		deliverRes := testApp.DeliverTx(abci.RequestDeliverTx{Tx: rawTx})
+		assert.Equal(t, types.ErrUnmalleatedWirePFM.ABCICode(), deliverRes.Code, tt.name)
+	}
+}
+
+func TestUnwrappedPayForMessage(t *testing.T) {
+	signer := generateKeyringSigner(t, "test")
+	testApp := setupApp(t, signer.GetSignerInfo().GetPubKey())
+	// commit the genesis state so that it can be used by PreprocessTxs
+	testApp.Commit()
+
+	rawTx := buildWireTx(t, testApp.txConfig, signer, func([]*types.MsgWirePayForMessage) {}, []byte{1, 2, 3})
+	res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: [][]byte{rawTx}})
+	require.Len(t, res.Txs, 1)
+	_, childTx, isMalleated := coretypes.UnwrapMalleatedTx(res.Txs[0])
+	require.True(t, isMalleated)
+
+	// the malleated tx can't be submitted to the mempool on its own
+	checkRes := testApp.CheckTx(abci.RequestCheckTx{Tx: childTx})
+	assert.Equal(t, types.ErrUnwrappedPFM.Codespace(), checkRes.Codespace)
+	assert.Equal(t, types.ErrUnwrappedPFM.ABCICode(), checkRes.Code)
+
+	// nor can it be executed without being wrapped
+	testApp.BeginBlock(abci.RequestBeginBlock{Header: core.Header{Height: 2, ChainID: testChainID}})
+	deliverRes := testApp.DeliverTx(abci.RequestDeliverTx{Tx: childTx})
+	assert.Equal(t, types.ErrUnwrappedPFM.Codespace(), deliverRes.Codespace)
+	assert.Equal(t, types.ErrUnwrappedPFM.ABCICode(), deliverRes.Code)
+
+	deliverRes = testApp.DeliverTx(abci.RequestDeliverTx{Tx: res.Txs[0]})
+	assert.Equal(t, abci.CodeTypeOK, deliverRes.Code, deliverRes.Log)
+}
+
+func TestMessageGasDecorator(t *testing.T) {
+	signer := generateKeyringSigner(t, "test")
+	testApp := setupApp(t, signer.GetSignerInfo().GetPubKey())
+	ctx := testApp.NewContext(false, core.Header{})
+	params := testApp.PaymentKeeper.GetParams(ctx)
+	decorator := paymentante.NewMessageGasDecorator(testApp.PaymentKeeper)
+
+	rawTx := buildWireTx(t, testApp.txConfig, signer, func([]*types.MsgWirePayForMessage) {},
+		bytes.Repeat([]byte{1}, 2*types.ShareSize),
+	)
+	wireTx, err := testApp.txConfig.TxDecoder()(rawTx)
+	require.NoError(t, err)
+
+	builder := testApp.txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(
+		&types.MsgPayForMessage{MessageSize: 2 * types.ShareSize},
+		&types.MsgPayForMessage{MessageSize: types.ShareSize},
+	))
+	childTx := builder.GetTx()
+
+	builder = testApp.txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(banktypes.NewMsgSend(
+		signer.GetSignerInfo().GetAddress(),
+		signer.GetSignerInfo().GetAddress(),
+		sdk.NewCoins(sdk.NewCoin(BondDenom, sdk.NewInt(1))),
+	)))
+	sendTx := builder.GetTx()
+
+	type test struct {
+		name     string
+		tx       sdk.Tx
+		expected uint64
+	}
+	tests := []test{
+		{
+			name:     "wire tx",
+			tx:       wireTx,
+			expected: 2*types.ShareSize*params.GasPerByte + 4*params.GasPerShareCommitment,
+		},
+		{
+			name:     "malleated tx",
+			tx:       childTx,
+			expected: 3*types.ShareSize*params.GasPerByte + 2*params.GasPerShareCommitment,
+		},
+		{
+			name:     "tx that does not pay for messages",
+			tx:       sendTx,
+			expected: 0,
+		},
+	}
+
+	// reading the params consumes gas as well
+	paramsCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	testApp.PaymentKeeper.GetParams(paramsCtx)
+	paramsGas := paramsCtx.GasMeter().GasConsumed()
+
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+	for _, tt := range tests {
+		txCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		_, err := decorator.AnteHandle(txCtx, tt.tx, false, next)
+		require.NoError(t, err, tt.name)
+		if tt.expected != 0 {
+			tt.expected += paramsGas
+		}
+		assert.Equal(t, tt.expected, txCtx.GasMeter().GasConsumed(), tt.name)
+	}
+
+	// txs that don't provide enough gas for their messages run out of gas
+	limitedCtx := ctx.WithGasMeter(sdk.NewGasMeter(paramsGas + 2*types.ShareSize*params.GasPerByte))
+	assert.Panics(t, func() {
+		_, _ = decorator.AnteHandle(limitedCtx, wireTx, false, next)
+	})
+}
+
+// buildWireTx creates a tx containing a MsgWirePayForMessage for each of the
+// provided messages, which commit to square sizes 2, 4, 8 and 16. The share
+// commitments are signed using the same gas limit and fees as the tx.
+func buildWireTx(
+	t *testing.T,
+	txConfig client.TxConfig,
+	signer *types.KeyringSigner,
+	mutate func([]*types.MsgWirePayForMessage),
+	messages ...[]byte,
+) []byte {
+	options := wireTxOptions()
+	wireMsgs := make([]*types.MsgWirePayForMessage, len(messages))
+	msgs := make([]sdk.Msg, len(messages))
+	for i, message := range messages {
+		ns := []byte{byte(i + 1), 1, 1, 1, 1, 1, 1, 1}
+		wireMsg, err := types.NewWirePayForMessage(ns, message, 2, 4, 8, 16)
+		require.NoError(t, err)
+		wireMsgs[i], msgs[i] = wireMsg, wireMsg
+	}
+	require.NoError(t, types.SignWirePayForMessages(signer, wireMsgs, options...))
+	mutate(wireMsgs)
+
+	builder := signer.NewTxBuilder()
+	for _, option := range options {
+		builder = option(builder)
+	}
+	tx, err := signer.BuildSignedTx(builder, msgs...)
+	require.NoError(t, err)
+
+	rawTx, err := txConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+	return rawTx
+}
+
+// wireTxOptions returns the gas limit and fees used by buildWireTx
+func wireTxOptions() []types.TxBuilderOption {
+	return []types.TxBuilderOption{
+		types.SetGasLimit(1000000),
+		types.SetFeeAmount(sdk.NewCoins(sdk.NewCoin("token", sdk.NewInt(1000)))),
+	}
+}
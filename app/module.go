@@ -9,6 +9,9 @@ import (
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	"github.com/cosmos/cosmos-sdk/x/staking"
@@ -78,6 +81,22 @@ func (crisisModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
 	})
 }
 
+type govModule struct {
+	gov.AppModuleBasic
+}
+
+func newGovModule(proposalHandlers ...govclient.ProposalHandler) govModule {
+	return govModule{gov.NewAppModuleBasic(proposalHandlers...)}
+}
+
+// DefaultGenesis returns custom x/gov module genesis state.
+func (govModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	genState := govtypes.DefaultGenesisState()
+	genState.DepositParams.MinDeposit = sdk.NewCoins(sdk.NewCoin(BondDenom, govtypes.DefaultMinDepositTokens))
+
+	return cdc.MustMarshalJSON(genState)
+}
+
 type mintModule struct {
 	mint.AppModuleBasic
 }
@@ -0,0 +1,349 @@
+package app
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	paymentkeeper "github.com/celestiaorg/celestia-app/x/payment/keeper"
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	paramproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/pkg/consts"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestPayForMessage(t *testing.T) {
+	type test struct {
+		name          string
+		pricePerShare int64
+		burnRatio     sdk.Dec
+		messageSize   uint64
+		expectErr     bool
+	}
+	tests := []test{
+		{"free messages", 0, sdk.OneDec(), 1024, false},
+		{"payment is burned", 2, sdk.OneDec(), 1024, false},
+		{"payment is sent to the fee collector", 2, sdk.ZeroDec(), 1024, false},
+		{"payment is split", 3, sdk.NewDecWithPrec(5, 1), 1024, false},
+		{"insufficient funds", 1000000, sdk.OneDec(), 1024, true},
+		{"message is too large", 0, sdk.OneDec(), types.DefaultMaxMessageBytes + 1, true},
+	}
+
+	for _, tt := range tests {
+		kb := keyring.NewInMemory()
+		info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+		require.NoError(t, err)
+		signer := sdk.AccAddress(info.GetPubKey().Address())
+
+		testApp := setupApp(t, info.GetPubKey())
+		ctx := testApp.NewContext(false, core.Header{})
+
+		params := testApp.PaymentKeeper.GetParams(ctx)
+		params.PricePerShare = sdk.NewCoin(BondDenom, sdk.NewInt(tt.pricePerShare))
+		params.BurnRatio = tt.burnRatio
+		testApp.PaymentKeeper.SetParams(ctx, params)
+
+		feeCollector := testApp.AccountKeeper.GetModuleAddress(authtypes.FeeCollectorName)
+		balanceBefore := testApp.BankKeeper.GetBalance(ctx, signer, BondDenom)
+		supplyBefore := testApp.BankKeeper.GetSupply(ctx, BondDenom)
+		collectedBefore := testApp.BankKeeper.GetBalance(ctx, feeCollector, BondDenom)
+
+		msgServer := paymentkeeper.NewMsgServerImpl(testApp.PaymentKeeper)
+		_, err = msgServer.PayForMessage(sdk.WrapSDKContext(ctx), &types.MsgPayForMessage{
+			Signer:             signer.String(),
+			MessageNamespaceId: []byte{1, 1, 1, 1, 1, 1, 1, 1},
+			MessageSize:        tt.messageSize,
+		})
+		if tt.expectErr {
+			assert.Error(t, err, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+
+		payment := sdk.NewInt(tt.pricePerShare).MulRaw(int64(types.MessageShares(tt.messageSize)))
+		assert.Equal(t, balanceBefore.Amount.Sub(payment), testApp.BankKeeper.GetBalance(ctx, signer, BondDenom).Amount, tt.name)
+
+		burned, collected := params.SplitPayment(sdk.NewCoin(BondDenom, payment))
+		assert.Equal(t, supplyBefore.Sub(burned), testApp.BankKeeper.GetSupply(ctx, BondDenom), tt.name)
+		assert.Equal(t, collectedBefore.Add(collected), testApp.BankKeeper.GetBalance(ctx, feeCollector, BondDenom), tt.name)
+	}
+}
+
+func TestParamChangeProposal(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+
+	testApp := setupApp(t, info.GetPubKey())
+	ctx := testApp.NewContext(false, core.Header{})
+	handler := params.NewParamChangeProposalHandler(testApp.ParamsKeeper)
+
+	proposal := paramproposal.NewParameterChangeProposal("title", "description", []paramproposal.ParamChange{
+		paramproposal.NewParamChange(types.ModuleName, string(types.KeyMaxMessageBytes), `"1024"`),
+		paramproposal.NewParamChange(types.ModuleName, string(types.KeyBurnRatio), `"0.5"`),
+		paramproposal.NewParamChange(types.ModuleName, string(types.KeyRequiredSquareSizes), `["16","32"]`),
+	})
+	require.NoError(t, handler(ctx, proposal))
+
+	got := testApp.PaymentKeeper.GetParams(ctx)
+	assert.Equal(t, uint64(1024), got.MaxMessageBytes)
+	assert.Equal(t, sdk.NewDecWithPrec(5, 1), got.BurnRatio)
+	assert.Equal(t, []uint64{16, 32}, got.RequiredSquareSizes)
+
+	// invalid values are rejected by the param validation
+	invalid := paramproposal.NewParameterChangeProposal("title", "description", []paramproposal.ParamChange{
+		paramproposal.NewParamChange(types.ModuleName, string(types.KeyBurnRatio), `"2"`),
+	})
+	assert.Error(t, handler(ctx, invalid))
+	assert.Equal(t, sdk.NewDecWithPrec(5, 1), testApp.PaymentKeeper.GetParams(ctx).BurnRatio)
+}
+
+func TestPaymentStats(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	signer := sdk.AccAddress(info.GetPubKey().Address())
+
+	testApp := setupApp(t, info.GetPubKey())
+	ctx := testApp.NewContext(false, core.Header{Height: 5})
+	goCtx := sdk.WrapSDKContext(ctx)
+
+	ns := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	msgServer := paymentkeeper.NewMsgServerImpl(testApp.PaymentKeeper)
+	for _, size := range []uint64{types.ShareSize, 2 * types.ShareSize} {
+		_, err = msgServer.PayForMessage(goCtx, &types.MsgPayForMessage{
+			Signer:             signer.String(),
+			MessageNamespaceId: ns,
+			MessageSize:        size,
+		})
+		require.NoError(t, err)
+	}
+
+	nsRes, err := testApp.PaymentKeeper.NamespaceStats(goCtx, &types.QueryNamespaceStatsRequest{NamespaceId: "0101010101010101"})
+	require.NoError(t, err)
+	assert.Equal(t, types.NamespaceStats{
+		NamespaceId:   ns,
+		TotalBytes:    3 * types.ShareSize,
+		TotalMessages: 2,
+		LastHeight:    5,
+	}, nsRes.Stats)
+
+	signerRes, err := testApp.PaymentKeeper.SignerStats(goCtx, &types.QuerySignerStatsRequest{Signer: signer.String()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3*types.ShareSize), signerRes.Stats.TotalBytes)
+	assert.Equal(t, uint64(2), signerRes.Stats.TotalMessages)
+	assert.Equal(t, int64(5), signerRes.Stats.LastHeight)
+	paid := testApp.PaymentKeeper.GetParams(ctx).PaymentForShares(types.MessageShares(types.ShareSize) + types.MessageShares(2*types.ShareSize))
+	assert.True(t, signerRes.Stats.TotalPaid.IsEqual(sdk.NewCoins(paid)))
+
+	// namespaces without any messages have empty stats
+	emptyRes, err := testApp.PaymentKeeper.NamespaceStats(goCtx, &types.QueryNamespaceStatsRequest{NamespaceId: "0202020202020202"})
+	require.NoError(t, err)
+	assert.Zero(t, emptyRes.Stats.TotalMessages)
+
+	_, err = testApp.PaymentKeeper.NamespaceStats(goCtx, &types.QueryNamespaceStatsRequest{NamespaceId: "0101"})
+	assert.Error(t, err)
+}
+
+func TestCommitmentQuery(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+
+	testApp := setupApp(t, info.GetPubKey())
+	goCtx := sdk.WrapSDKContext(testApp.NewContext(true, core.Header{}))
+
+	ns := []byte{1, 1, 1, 1, 1, 1, 1, 1}
+	message := []byte{1, 2, 3}
+	wireMsg, err := types.NewWirePayForMessage(ns, message, 4)
+	require.NoError(t, err)
+
+	res, err := testApp.PaymentKeeper.Commitment(goCtx, &types.QueryCommitmentRequest{
+		NamespaceId: "0101010101010101",
+		Message:     message,
+		SquareSize:  4,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, wireMsg.MessageShareCommitment[0].ShareCommitment, res.ShareCommitment)
+	assert.Equal(t, wireMsg.MessageSize, res.MessageSize)
+	assert.Equal(t, uint64(2), res.Shares)
+
+	_, err = testApp.PaymentKeeper.Commitment(goCtx, &types.QueryCommitmentRequest{
+		NamespaceId: "0101010101010101",
+		Message:     message,
+		SquareSize:  3,
+	})
+	assert.Error(t, err)
+
+	// the square size and message size are bounded by the params
+	_, err = testApp.PaymentKeeper.Commitment(goCtx, &types.QueryCommitmentRequest{
+		NamespaceId: "0101010101010101",
+		Message:     message,
+		SquareSize:  2 * consts.MaxSquareSize,
+	})
+	assert.Error(t, err)
+
+	ctx := testApp.NewContext(true, core.Header{})
+	params := testApp.PaymentKeeper.GetParams(ctx)
+	params.MaxMessageBytes = types.ShareSize
+	testApp.PaymentKeeper.SetParams(ctx, params)
+	_, err = testApp.PaymentKeeper.Commitment(goCtx, &types.QueryCommitmentRequest{
+		NamespaceId: "0101010101010101",
+		Message:     make([]byte, types.ShareSize+1),
+		SquareSize:  4,
+	})
+	assert.Error(t, err)
+}
+
+func TestPayForMessageEvents(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	require.NoError(t, err)
+	signer := sdk.AccAddress(info.GetPubKey().Address())
+
+	testApp := setupApp(t, info.GetPubKey())
+	ctx := testApp.NewContext(false, core.Header{}).WithEventManager(sdk.NewEventManager())
+
+	msg := &types.MsgPayForMessage{
+		Signer:                 signer.String(),
+		MessageNamespaceId:     []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		MessageSize:            types.ShareSize,
+		MessageShareCommitment: []byte{0xab, 0xcd},
+	}
+	msgServer := paymentkeeper.NewMsgServerImpl(testApp.PaymentKeeper)
+	_, err = msgServer.PayForMessage(sdk.WrapSDKContext(ctx), msg)
+	require.NoError(t, err)
+
+	payment := testApp.PaymentKeeper.GetParams(ctx).PaymentForShares(types.MessageShares(msg.MessageSize))
+	expected := map[string]string{
+		types.AttributeKeySigner:          signer.String(),
+		types.AttributeKeyNamespace:       "0102030405060708",
+		types.AttributeKeyMessageSize:     "256",
+		types.AttributeKeyShareCommitment: "abcd",
+		types.AttributeKeyFeePaid:         payment.String(),
+	}
+
+	var typed, flat bool
+	for _, event := range ctx.EventManager().Events() {
+		switch event.Type {
+		case "payment.EventPayForMessage":
+			typed = true
+			parsed, err := sdk.ParseTypedEvent(abci.Event(event))
+			require.NoError(t, err)
+			assert.Equal(t, &types.EventPayForMessage{
+				Signer:          signer.String(),
+				NamespaceId:     "0102030405060708",
+				MessageSize:     types.ShareSize,
+				ShareCommitment: "abcd",
+				FeePaid:         payment,
+			}, parsed)
+		case types.EventTypePayForMessage:
+			flat = true
+			attrs := make(map[string]string)
+			for _, attr := range event.Attributes {
+				attrs[string(attr.Key)] = string(attr.Value)
+			}
+			assert.Equal(t, expected, attrs)
+		}
+	}
+	assert.True(t, typed)
+	assert.True(t, flat)
+}
+
+func TestMalleatedTxIndex(t *testing.T) {
+	signer := generateKeyringSigner(t, "test")
+	testApp := setupApp(t, signer.GetSignerInfo().GetPubKey())
+	// commit the genesis state so that it can be used by PreprocessTxs
+	testApp.Commit()
+
+	rawTx := buildWireTx(t, testApp.txConfig, signer, func([]*types.MsgWirePayForMessage) {}, []byte{1, 2, 3})
+	res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: [][]byte{rawTx}})
+	require.Len(t, res.Txs, 1)
+
+	testApp.BeginBlock(abci.RequestBeginBlock{Header: core.Header{Height: 2, ChainID: testChainID}})
+	deliverRes := testApp.DeliverTx(abci.RequestDeliverTx{Tx: res.Txs[0]})
+	require.Equal(t, abci.CodeTypeOK, deliverRes.Code, deliverRes.Log)
+
+	_, childTx, isMalleated := coretypes.UnwrapMalleatedTx(res.Txs[0])
+	require.True(t, isMalleated)
+
+	goCtx := sdk.WrapSDKContext(testApp.NewContext(false, core.Header{}))
+	queryRes, err := testApp.PaymentKeeper.MalleatedTx(goCtx, &types.QueryMalleatedTxRequest{
+		ParentHash: hex.EncodeToString(tmhash.Sum(rawTx)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.MalleatedTx{
+		ParentHash: fmt.Sprintf("%X", tmhash.Sum(rawTx)),
+		Hash:       fmt.Sprintf("%X", tmhash.Sum(childTx)),
+		Height:     2,
+	}, queryRes.MalleatedTx)
+
+	// txs that were not malleated are not indexed
+	_, err = testApp.PaymentKeeper.MalleatedTx(goCtx, &types.QueryMalleatedTxRequest{
+		ParentHash: hex.EncodeToString(tmhash.Sum(childTx)),
+	})
+	assert.Error(t, err)
+}
+
+func TestFeeGrantedPayForMessage(t *testing.T) {
+	signer := generateKeyringSigner(t, "test")
+	grantee := signer.GetSignerInfo().GetAddress()
+	granter := sdk.AccAddress(tmhash.SumTruncated([]byte("granter")))
+	testApp := setupApp(t, signer.GetSignerInfo().GetPubKey())
+	// commit the genesis state so that it can be used by the next block
+	testApp.Commit()
+
+	// the granter pays the fees of the signer
+	fees := sdk.NewCoins(sdk.NewCoin("token", sdk.NewInt(1000)))
+	testApp.BeginBlock(abci.RequestBeginBlock{Header: core.Header{Height: 2, ChainID: testChainID}})
+	ctx := testApp.NewContext(false, core.Header{Height: 2})
+	require.NoError(t, testApp.BankKeeper.SendCoins(ctx, grantee, granter, fees))
+	require.NoError(t, testApp.FeeGrantKeeper.GrantAllowance(ctx, granter, grantee, &feegrant.BasicAllowance{}))
+	testApp.EndBlock(abci.RequestEndBlock{Height: 2})
+	testApp.Commit()
+
+	options := append(wireTxOptions(), types.SetFeeGranter(granter))
+	wireMsg, err := types.NewWirePayForMessage([]byte{1, 1, 1, 1, 1, 1, 1, 1}, []byte{1, 2, 3}, 2, 4, 8, 16)
+	require.NoError(t, err)
+	require.NoError(t, types.SignWirePayForMessages(signer, []*types.MsgWirePayForMessage{wireMsg}, options...))
+	builder := signer.NewTxBuilder()
+	for _, option := range options {
+		builder = option(builder)
+	}
+	tx, err := signer.BuildSignedTx(builder, wireMsg)
+	require.NoError(t, err)
+	rawTx, err := testApp.txConfig.TxEncoder()(tx)
+	require.NoError(t, err)
+
+	// the signatures of the share commitments cover the fee granter
+	checkRes := testApp.CheckTx(abci.RequestCheckTx{Tx: rawTx})
+	require.Equal(t, abci.CodeTypeOK, checkRes.Code, checkRes.Log)
+
+	res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: [][]byte{rawTx}})
+	require.Len(t, res.Txs, 1)
+	_, childTx, isMalleated := coretypes.UnwrapMalleatedTx(res.Txs[0])
+	require.True(t, isMalleated)
+	malleatedTx, err := testApp.txConfig.TxDecoder()(childTx)
+	require.NoError(t, err)
+	assert.Equal(t, granter, malleatedTx.(sdk.FeeTx).FeeGranter())
+
+	testApp.BeginBlock(abci.RequestBeginBlock{Header: core.Header{Height: 3, ChainID: testChainID}})
+	granteeTokens := testApp.BankKeeper.GetBalance(testApp.NewContext(false, core.Header{}), grantee, "token")
+	deliverRes := testApp.DeliverTx(abci.RequestDeliverTx{Tx: res.Txs[0]})
+	require.Equal(t, abci.CodeTypeOK, deliverRes.Code, deliverRes.Log)
+
+	ctx = testApp.NewContext(false, core.Header{})
+	assert.True(t, testApp.BankKeeper.GetBalance(ctx, granter, "token").IsZero())
+	assert.Equal(t, granteeTokens, testApp.BankKeeper.GetBalance(ctx, grantee, "token"))
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// blockTx is a transaction that is a candidate for inclusion in the block
+// being proposed. Normal transactions only populate the raw bytes and signers,
+// while transactions containing MsgWirePayForMessages also carry the messages
+// they pay for and their priority.
+type blockTx struct {
+	// raw is the encoded transaction that is included in the block. Once a
+	// PayForMessage tx is malleated, this is the malleated tx wrapped with the
+	// hash of the original tx.
+	raw     []byte
+	signers []string
+
+	// the following fields are only set for PayForMessage txs, and msgs is
+	// only set once the tx is malleated
+	authTx      signing.Tx
+	wireMsgs    []*types.MsgWirePayForMessage
+	parentHash  []byte
+	msgs        []*core.Message
+	feePerShare sdk.Dec
+}
+
+func (btx *blockTx) isPayForMessage() bool {
+	return len(btx.wireMsgs) != 0
+}
+
+// commitsTo checks if every MsgWirePayForMessage includes a share commitment
+// for the provided square size
+func (btx *blockTx) commitsTo(squareSize uint64) bool {
+	for _, wireMsg := range btx.wireMsgs {
+		committed := false
+		for _, commit := range wireMsg.MessageShareCommitment {
+			if commit.K == squareSize {
+				committed = true
+				break
+			}
+		}
+		if !committed {
+			return false
+		}
+	}
+	return true
+}
+
+// anyCommittedSquareSize returns a square size that every
+// MsgWirePayForMessage includes a share commitment for
+func (btx *blockTx) anyCommittedSquareSize() (uint64, bool) {
+	for _, commit := range btx.wireMsgs[0].MessageShareCommitment {
+		if btx.commitsTo(commit.K) {
+			return commit.K, true
+		}
+	}
+	return 0, false
+}
+
+// feePerShare calculates the priority of a tx containing MsgWirePayForMessages
+// using the fee paid in the native denomination and the total number of shares
+// used by its messages.
+func feePerShare(fee sdk.Coins, wireMsgs []*types.MsgWirePayForMessage) sdk.Dec {
+	shares := uint64(0)
+	for _, wireMsg := range wireMsgs {
+		shares += messageShares(len(wireMsg.Message))
+	}
+	return sdk.NewDecFromInt(fee.AmountOf(BondDenom)).QuoInt64(int64(shares))
+}
+
+// prioritize selects which of the provided txs should be included in a block,
+// using the share counter to make sure that the selected txs fit in the
+// square. Normal transactions are included first, while PayForMessage
+// transactions are ranked by the fee they pay per share and greedily packed
+// into the remaining space. A transaction that does not fit does not stop the
+// packing, instead smaller transactions that still fit are included. The
+// selected txs are returned in their original order.
+//
+// Since the transactions of a signer must be executed in the order of their
+// sequence, a PayForMessage is never ranked above an earlier transaction of the
+// same signer, and once a transaction of a signer is skipped, all of the later
+// transactions of that signer are skipped as well. Skipped transactions are not
+// removed from the mempool, so they are considered again for the next block.
+// Once prioritize returns, the share counter only counts the selected txs.
+func prioritize(txs []*blockTx, counter *shareCounter) []*blockTx {
+	// the priority of a PayForMessage is capped at the priority of the
+	// earlier PayForMessages of the same signer
+	priorities := make([]sdk.Dec, len(txs))
+	lowest := make(map[string]sdk.Dec)
+	var pfmIndexes []int
+	for i, btx := range txs {
+		if !btx.isPayForMessage() {
+			continue
+		}
+		priority := btx.feePerShare
+		for _, signer := range btx.signers {
+			if low, has := lowest[signer]; has && low.LT(priority) {
+				priority = low
+			}
+		}
+		for _, signer := range btx.signers {
+			lowest[signer] = priority
+		}
+		priorities[i] = priority
+		pfmIndexes = append(pfmIndexes, i)
+	}
+
+	// rank the PayForMessages, using the original order to break ties
+	sort.SliceStable(pfmIndexes, func(i, j int) bool {
+		return priorities[pfmIndexes[i]].GT(priorities[pfmIndexes[j]])
+	})
+
+	// skippedAt tracks the index of the first skipped tx for each signer
+	skippedAt := make(map[string]int)
+	isSkipped := func(i int) bool {
+		for _, signer := range txs[i].signers {
+			if skipped, has := skippedAt[signer]; has && skipped < i {
+				return true
+			}
+		}
+		return false
+	}
+	skip := func(i int) {
+		for _, signer := range txs[i].signers {
+			if skipped, has := skippedAt[signer]; !has || i < skipped {
+				skippedAt[signer] = i
+			}
+		}
+	}
+
+	selected := make([]bool, len(txs))
+	for i, btx := range txs {
+		if btx.isPayForMessage() {
+			continue
+		}
+		if isSkipped(i) || !counter.addTx(btx.raw) {
+			skip(i)
+			continue
+		}
+		selected[i] = true
+	}
+	for _, i := range pfmIndexes {
+		if isSkipped(i) || !counter.addPayForMessage(txs[i].raw, txs[i].msgs...) {
+			skip(i)
+			continue
+		}
+		selected[i] = true
+	}
+
+	var included []*blockTx
+	for i, btx := range txs {
+		if selected[i] && !isSkipped(i) {
+			included = append(included, btx)
+		}
+	}
+
+	// a tx that was counted can still be left out once an earlier tx of its
+	// signer is skipped, so the counter is rebuilt from the included txs. They
+	// are a subset of the counted txs, so all of them fit.
+	*counter = *newShareCounter(counter.squareSize)
+	for _, btx := range included {
+		if btx.isPayForMessage() {
+			counter.addPayForMessage(btx.raw, btx.msgs...)
+			continue
+		}
+		counter.addTx(btx.raw)
+	}
+	return included
+}
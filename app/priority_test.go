@@ -0,0 +1,155 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/pkg/consts"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestPrioritize(t *testing.T) {
+	type test struct {
+		name       string
+		txs        []*blockTx
+		squareSize uint64
+		expected   []string
+	}
+
+	normalTx := func(name, signer string) *blockTx {
+		return &blockTx{raw: []byte(name), signers: []string{signer}}
+	}
+	bigTx := func(name, signer string, shares int) *blockTx {
+		raw := make([]byte, shares*consts.TxShareSize)
+		copy(raw, name)
+		return &blockTx{raw: raw, signers: []string{signer}}
+	}
+	pfmTx := func(name, signer string, shares int, feePerShare int64) *blockTx {
+		return &blockTx{
+			raw:         []byte(name),
+			signers:     []string{signer},
+			wireMsgs:    []*types.MsgWirePayForMessage{{}},
+			msgs:        []*core.Message{{Data: make([]byte, shares*consts.MsgShareSize-2)}},
+			feePerShare: sdk.NewDec(feePerShare),
+		}
+	}
+
+	tests := []test{
+		{
+			name: "everything fits",
+			txs: []*blockTx{
+				normalTx("send", "a"),
+				pfmTx("pfm1", "b", 4, 1),
+				pfmTx("pfm2", "c", 4, 2),
+			},
+			squareSize: 4,
+			expected:   []string{"send", "pfm1", "pfm2"},
+		},
+		{
+			name: "higher fee per share wins over mempool order",
+			txs: []*blockTx{
+				pfmTx("cheap", "a", 12, 1),
+				pfmTx("expensive", "b", 8, 10),
+				pfmTx("small", "c", 4, 5),
+			},
+			squareSize: 4,
+			expected:   []string{"expensive", "small"},
+		},
+		{
+			name: "smaller txs are packed after a large one is skipped",
+			txs: []*blockTx{
+				pfmTx("first", "a", 10, 10),
+				pfmTx("large", "b", 10, 5),
+				pfmTx("small", "c", 2, 1),
+			},
+			squareSize: 4,
+			expected:   []string{"first", "small"},
+		},
+		{
+			name: "later txs of a signer are skipped once one is skipped",
+			txs: []*blockTx{
+				pfmTx("a1", "a", 12, 1),
+				pfmTx("a2", "a", 2, 100),
+				normalTx("a3", "a"),
+				pfmTx("b1", "b", 8, 5),
+				normalTx("c1", "c"),
+			},
+			squareSize: 4,
+			expected:   []string{"b1", "c1"},
+		},
+		{
+			name: "a signer's txs are included in order",
+			txs: []*blockTx{
+				pfmTx("a1", "a", 2, 1),
+				pfmTx("a2", "a", 2, 100),
+				pfmTx("b1", "b", 2, 5),
+			},
+			squareSize: 4,
+			expected:   []string{"a1", "a2", "b1"},
+		},
+		{
+			name: "normal txs that don't fit are skipped",
+			txs: []*blockTx{
+				normalTx("send", "a"),
+				bigTx("big", "b", 4),
+				pfmTx("pfm", "c", 2, 1),
+			},
+			squareSize: 2,
+			expected:   []string{"send", "pfm"},
+		},
+	}
+
+	for _, tt := range tests {
+		res := prioritize(tt.txs, newShareCounter(tt.squareSize))
+		names := make([]string, len(res))
+		for i, btx := range res {
+			names[i] = string(bytes.TrimRight(btx.raw, "\x00"))
+		}
+		assert.Equal(t, tt.expected, names, tt.name)
+	}
+}
+
+func TestPrioritizeShareCount(t *testing.T) {
+	// the normal tx of a is counted before the PayForMessage of a that
+	// precedes it is skipped, so it has to be removed from the count
+	txs := []*blockTx{
+		{
+			raw:         []byte("a1"),
+			signers:     []string{"a"},
+			wireMsgs:    []*types.MsgWirePayForMessage{{}},
+			msgs:        []*core.Message{{NamespaceId: []byte{1, 0, 0, 0, 0, 0, 0, 0}, Data: make([]byte, 20*consts.MsgShareSize)}},
+			feePerShare: sdk.NewDec(1),
+		},
+		{raw: bytes.Repeat([]byte{2}, 3*consts.TxShareSize), signers: []string{"a"}},
+		{
+			raw:         []byte("b1"),
+			signers:     []string{"b"},
+			wireMsgs:    []*types.MsgWirePayForMessage{{}},
+			msgs:        []*core.Message{{NamespaceId: []byte{2, 0, 0, 0, 0, 0, 0, 0}, Data: make([]byte, 100)}},
+			feePerShare: sdk.NewDec(5),
+		},
+		{raw: []byte("c1"), signers: []string{"c"}},
+	}
+
+	counter := newShareCounter(4)
+	included := prioritize(txs, counter)
+	require.Len(t, included, 2)
+
+	data := coretypes.Data{}
+	for _, btx := range included {
+		data.Txs = append(data.Txs, btx.raw)
+		for _, msg := range btx.msgs {
+			data.Messages.MessagesList = append(data.Messages.MessagesList, coretypes.Message{
+				NamespaceID: msg.NamespaceId,
+				Data:        msg.Data,
+			})
+		}
+	}
+	_, coreShares := data.ComputeShares()
+	assert.Equal(t, uint64(coreShares), counter.size())
+}
@@ -0,0 +1,191 @@
+package app
+
+import (
+	"bytes"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/pkg/consts"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// ProcessProposal is the validator side counterpart of PreprocessTxs. It
+// verifies that the block data proposed by the block producer is valid: the
+// square that celestia-core builds from the block data must be allowed by the
+// payment module's params, the messages must be ordered by namespace, and every
+// malleated MsgPayForMessage must pay for a message in the block that matches
+// its share commitment for the size of that square. Every message must also be
+// paid for by exactly one MsgPayForMessage. A nil error is returned if the
+// proposal is valid.
+//
+// The ABCI of celestia-core only has PreprocessTxs for the block producer, and
+// no method that validators are called with to accept or reject the block
+// data of a proposal, so nothing calls ProcessProposal yet and proposals are
+// not rejected by it. It is the check to run once celestia-core calls the app
+// to process proposals.
+func (app *App) ProcessProposal(data *core.Data) error {
+	// ProcessProposal is not called with a context, so the params are read from
+	// the latest committed state
+	params := app.PaymentKeeper.GetParams(app.NewContext(true, core.Header{}))
+
+	if err := validateMessageOrder(data.Messages.MessagesList); err != nil {
+		return err
+	}
+
+	squareSize, err := dataSquareSize(data)
+	if err != nil {
+		return err
+	}
+	if squareSize > params.MaxSquareSize {
+		return sdkerrors.Wrapf(
+			types.ErrSquareOverflow,
+			"square size %d is larger than %d",
+			squareSize,
+			params.MaxSquareSize,
+		)
+	}
+	// celestia-core does not pad the square to the min square size, so only
+	// the squares that contain messages are required to be large enough
+	if squareSize < params.MinSquareSize && len(data.Messages.MessagesList) != 0 {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidSquareSize,
+			"square size %d is smaller than %d",
+			squareSize,
+			params.MinSquareSize,
+		)
+	}
+
+	// index the messages by namespace, so that each MsgPayForMessage only has
+	// to compute commitments for the messages in its namespace
+	unpaid := make(map[string][]*core.Message)
+	for _, msg := range data.Messages.MessagesList {
+		ns := string(msg.NamespaceId)
+		unpaid[ns] = append(unpaid[ns], msg)
+	}
+
+	for _, rawTx := range data.Txs {
+		_, childTx, isMalleated := coretypes.UnwrapMalleatedTx(rawTx)
+		if !isMalleated {
+			childTx = rawTx
+		}
+
+		tx, err := app.txConfig.TxDecoder()(childTx)
+		if err != nil {
+			// a malleated tx is created by the block producer, so it must
+			// always decode. Other txs that fail to decode are rejected by
+			// the ante handler when the block is executed.
+			if isMalleated {
+				return sdkerrors.Wrap(types.ErrInvalidMalleatedTx, err.Error())
+			}
+			continue
+		}
+
+		for _, msg := range tx.GetMsgs() {
+			switch sdk.MsgTypeURL(msg) {
+			case types.URLMsgWirePayforMessage:
+				return types.ErrUnmalleatedWirePFM
+			case types.URLMsgPayforMessage:
+				// the ante handler rejects a MsgPayForMessage that is not in
+				// a malleated tx, so it would not pay for its message
+				if !isMalleated {
+					return types.ErrUnwrappedPFM
+				}
+				pfm, ok := msg.(*types.MsgPayForMessage)
+				if !ok {
+					return sdkerrors.Wrapf(types.ErrInvalidMalleatedTx, "unexpected msg type %T", msg)
+				}
+				if err := payForMessage(unpaid, pfm, squareSize); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for ns, msgs := range unpaid {
+		if len(msgs) != 0 {
+			return sdkerrors.Wrapf(types.ErrUnpaidMessage, "namespace %X", []byte(ns))
+		}
+	}
+
+	return nil
+}
+
+// validateMessageOrder checks that the messages are sorted by namespace, and
+// that none of them use a reserved namespace
+func validateMessageOrder(msgs []*core.Message) error {
+	for i, msg := range msgs {
+		if bytes.Compare(msg.NamespaceId, consts.MaxReservedNamespace) < 1 {
+			return sdkerrors.Wrapf(types.ErrReservedNamespace, "namespace %X", msg.NamespaceId)
+		}
+		if i > 0 && bytes.Compare(msgs[i-1].NamespaceId, msg.NamespaceId) > 0 {
+			return sdkerrors.Wrapf(
+				types.ErrUnorderedMessages,
+				"namespace %X comes after %X",
+				msg.NamespaceId,
+				msgs[i-1].NamespaceId,
+			)
+		}
+	}
+	return nil
+}
+
+// dataSquareSize returns the size of the square that celestia-core builds from
+// the block data
+func dataSquareSize(data *core.Data) (uint64, error) {
+	counter := newShareCounter(consts.MaxSquareSize)
+	for _, tx := range data.Txs {
+		if !counter.addTx(tx) {
+			return 0, types.ErrSquareOverflow
+		}
+	}
+	for _, root := range data.IntermediateStateRoots.RawRootsList {
+		if !counter.addIntermediateStateRoot(root) {
+			return 0, types.ErrSquareOverflow
+		}
+	}
+	for _, ev := range data.Evidence.Evidence {
+		rawEvidence, err := ev.Marshal()
+		if err != nil {
+			return 0, err
+		}
+		if !counter.addEvidence(rawEvidence) {
+			return 0, types.ErrSquareOverflow
+		}
+	}
+	for _, msg := range data.Messages.MessagesList {
+		if !counter.addMessage(msg) {
+			return 0, types.ErrSquareOverflow
+		}
+	}
+	return types.SquareSizeForShares(counter.size()), nil
+}
+
+// payForMessage finds the unpaid message that matches the size and share
+// commitment of the provided MsgPayForMessage, and removes it from the unpaid
+// messages. The share commitment must be for the square size of the block.
+func payForMessage(unpaid map[string][]*core.Message, pfm *types.MsgPayForMessage, squareSize uint64) error {
+	ns := string(pfm.MessageNamespaceId)
+	candidates := unpaid[ns]
+	for i, msg := range candidates {
+		if uint64(len(msg.Data)) != pfm.MessageSize {
+			continue
+		}
+		commits, err := types.CachedCommitments(msg.NamespaceId, msg.Data, squareSize)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(commits[0], pfm.MessageShareCommitment) {
+			continue
+		}
+		unpaid[ns] = append(candidates[:i:i], candidates[i+1:]...)
+		return nil
+	}
+	return sdkerrors.Wrapf(
+		types.ErrMissingMessage,
+		"namespace %X, size %d",
+		pfm.MessageNamespaceId,
+		pfm.MessageSize,
+	)
+}
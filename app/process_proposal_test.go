@@ -0,0 +1,153 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/pkg/consts"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestProcessProposal(t *testing.T) {
+	kb := keyring.NewInMemory()
+	info, _, err := kb.NewMnemonic(testingKeyAcc, keyring.English, "", "", hd.Secp256k1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	testApp := setupApp(t, info.GetPubKey())
+
+	firstRawTx := generateRawTx(t, testApp.txConfig, []byte{2, 2, 2, 2, 2, 2, 2, 2}, bytes.Repeat([]byte{2}, 512), kb)
+	secondRawTx := generateRawTx(t, testApp.txConfig, []byte{1, 1, 1, 1, 1, 1, 1, 1}, []byte{2}, kb)
+	thirdRawTx := generateRawTx(t, testApp.txConfig, []byte{3, 3, 3, 3, 3, 3, 3, 3}, []byte{}, kb)
+	rawTxs := [][]byte{firstRawTx, secondRawTx, thirdRawTx}
+
+	res := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: rawTxs})
+	require.Len(t, res.Txs, 3)
+
+	// validProposal returns a copy of the proposed block data that can be
+	// modified by each test
+	validProposal := func() *core.Data {
+		msgs := make([]*core.Message, len(res.Messages.MessagesList))
+		for i, msg := range res.Messages.MessagesList {
+			msgs[i] = &core.Message{
+				NamespaceId: msg.NamespaceId,
+				Data:        append([]byte{}, msg.Data...),
+			}
+		}
+		return &core.Data{
+			Txs:      append([][]byte{}, res.Txs...),
+			Messages: core.Messages{MessagesList: msgs},
+		}
+	}
+
+	type test struct {
+		name     string
+		mutate   func(*core.Data)
+		expected error
+	}
+	tests := []test{
+		{
+			name:   "valid proposal",
+			mutate: func(*core.Data) {},
+		},
+		{
+			name: "block data does not fit the largest square",
+			mutate: func(d *core.Data) {
+				d.Txs = append(d.Txs, make([]byte, consts.MaxShareCount*consts.TxShareSize))
+			},
+			expected: types.ErrSquareOverflow,
+		},
+		{
+			name: "messages are out of order",
+			mutate: func(d *core.Data) {
+				msgs := d.Messages.MessagesList
+				msgs[0], msgs[1] = msgs[1], msgs[0]
+			},
+			expected: types.ErrUnorderedMessages,
+		},
+		{
+			name: "message uses a reserved namespace",
+			mutate: func(d *core.Data) {
+				d.Messages.MessagesList[0].NamespaceId = []byte{0, 0, 0, 0, 0, 0, 0, 1}
+			},
+			expected: types.ErrReservedNamespace,
+		},
+		{
+			name: "message data does not match the commitment",
+			mutate: func(d *core.Data) {
+				d.Messages.MessagesList[1].Data[0]++
+			},
+			expected: types.ErrMissingMessage,
+		},
+		{
+			name: "message is missing",
+			mutate: func(d *core.Data) {
+				d.Messages.MessagesList = d.Messages.MessagesList[1:]
+			},
+			expected: types.ErrMissingMessage,
+		},
+		{
+			name: "message is not paid for",
+			mutate: func(d *core.Data) {
+				d.Messages.MessagesList = append(d.Messages.MessagesList, &core.Message{
+					NamespaceId: []byte{4, 4, 4, 4, 4, 4, 4, 4},
+					Data:        bytes.Repeat([]byte{4}, types.ShareSize),
+				})
+			},
+			expected: types.ErrUnpaidMessage,
+		},
+		{
+			name: "malleated tx was not wrapped",
+			mutate: func(d *core.Data) {
+				_, childTx, _ := coretypes.UnwrapMalleatedTx(d.Txs[0])
+				d.Txs[0] = childTx
+			},
+			expected: types.ErrUnwrappedPFM,
+		},
+		{
+			name: "wire tx was not malleated",
+			mutate: func(d *core.Data) {
+				d.Txs = append([][]byte{firstRawTx}, d.Txs...)
+			},
+			expected: types.ErrUnmalleatedWirePFM,
+		},
+	}
+
+	for _, tt := range tests {
+		data := validProposal()
+		tt.mutate(data)
+		err := testApp.ProcessProposal(data)
+		if tt.expected == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		assert.ErrorIs(t, err, tt.expected, tt.name)
+	}
+
+	// the share commitment of a message that spans several rows depends on the
+	// square size, so it doesn't match once an extra tx grows the square that
+	// celestia-core builds
+	largeRawTx := generateRawTx(t, testApp.txConfig, []byte{4, 4, 4, 4, 4, 4, 4, 4}, bytes.Repeat([]byte{4}, 20*types.ShareSize), kb)
+	largeRes := testApp.PreprocessTxs(abci.RequestPreprocessTxs{Txs: [][]byte{largeRawTx}})
+	require.Len(t, largeRes.Txs, 1)
+	require.Equal(t, uint64(8), proposedSquareSize(testApp, [][]byte{largeRawTx}))
+	largeProposal := &core.Data{Txs: largeRes.Txs, Messages: *largeRes.Messages}
+	assert.NoError(t, testApp.ProcessProposal(largeProposal))
+	largeProposal.Txs = append(largeProposal.Txs, make([]byte, 64*consts.TxShareSize))
+	assert.ErrorIs(t, testApp.ProcessProposal(largeProposal), types.ErrMissingMessage)
+
+	// messages can't be paid for in a square smaller than the min square size
+	ctx := testApp.NewContext(true, core.Header{})
+	params := testApp.PaymentKeeper.GetParams(ctx)
+	params.MinSquareSize = consts.MaxSquareSize
+	testApp.PaymentKeeper.SetParams(ctx, params)
+	assert.ErrorIs(t, testApp.ProcessProposal(validProposal()), types.ErrInvalidSquareSize)
+}
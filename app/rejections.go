@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/armon/go-metrics"
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// rejection records why a tx was left out of the block being proposed
+type rejection struct {
+	txHash []byte
+	reason types.RejectionReason
+	err    error
+}
+
+func newRejection(rawTx []byte, reason types.RejectionReason, err error) rejection {
+	return rejection{txHash: coretypes.Tx(rawTx).Hash(), reason: reason, err: err}
+}
+
+// reportRejections logs each rejection, increments the rejected tx counter for
+// its reason, and stores it so that it can be queried by the submitter
+func (app *App) reportRejections(rejections []rejection) {
+	// the block being proposed is built on top of the last committed block
+	height := app.LastBlockHeight() + 1
+	for _, r := range rejections {
+		txHash := fmt.Sprintf("%X", r.txHash)
+		app.Logger().Info(
+			"rejected tx",
+			"module", fmt.Sprintf("x/%s", types.ModuleName),
+			"tx_hash", txHash,
+			"reason", r.reason.String(),
+			"height", height,
+			"err", r.err,
+		)
+		telemetry.IncrCounterWithLabels(
+			[]string{types.ModuleName, "rejected_txs"},
+			1,
+			[]metrics.Label{telemetry.NewLabel("reason", r.reason.String())},
+		)
+		app.PaymentKeeper.Rejections().Add(types.Rejection{
+			TxHash: txHash,
+			Reason: r.reason,
+			Error:  r.err.Error(),
+			Height: height,
+		})
+	}
+}
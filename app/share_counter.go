@@ -0,0 +1,139 @@
+package app
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/tendermint/tendermint/pkg/consts"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+)
+
+// shareCounter keeps track of the exact number of shares that the data of a
+// block takes up in the original data square. It uses the same layout as
+// celestia-core's Data.ComputeShares: transactions, intermediate state roots,
+// and evidence are written contiguously into their reserved namespaces,
+// followed by the messages. Each message starts in a new share, but
+// celestia-core does not add any padding between messages, so the messages
+// take up the same number of shares regardless of their order.
+type shareCounter struct {
+	squareSize uint64
+
+	// the length delimited bytes written to each reserved namespace
+	txBytes       uint64
+	isrBytes      uint64
+	evidenceBytes uint64
+
+	msgShares uint64
+}
+
+func newShareCounter(squareSize uint64) *shareCounter {
+	return &shareCounter{squareSize: squareSize}
+}
+
+// addTx adds a transaction to the block if there is enough room in the square
+// for it. Returns false if the tx was not added.
+func (sc *shareCounter) addTx(tx []byte) bool {
+	sc.txBytes += delimitedLen(len(tx))
+	if !sc.fits() {
+		sc.txBytes -= delimitedLen(len(tx))
+		return false
+	}
+	return true
+}
+
+// addIntermediateStateRoot adds an intermediate state root to the block if
+// there is enough room in the square for it. Returns false if the root was not
+// added.
+func (sc *shareCounter) addIntermediateStateRoot(root []byte) bool {
+	sc.isrBytes += delimitedLen(len(root))
+	if !sc.fits() {
+		sc.isrBytes -= delimitedLen(len(root))
+		return false
+	}
+	return true
+}
+
+// addEvidence adds the protobuf encoding of a piece of evidence to the block if
+// there is enough room in the square for it. Returns false if the evidence was
+// not added.
+func (sc *shareCounter) addEvidence(rawEvidence []byte) bool {
+	sc.evidenceBytes += delimitedLen(len(rawEvidence))
+	if !sc.fits() {
+		sc.evidenceBytes -= delimitedLen(len(rawEvidence))
+		return false
+	}
+	return true
+}
+
+// addMessage adds a message to the block if there is enough room in the square
+// for it. Returns false if the message was not added.
+func (sc *shareCounter) addMessage(msg *core.Message) bool {
+	sc.msgShares += messageShares(len(msg.Data))
+	if !sc.fits() {
+		sc.msgShares -= messageShares(len(msg.Data))
+		return false
+	}
+	return true
+}
+
+// addPayForMessage adds both the malleated transaction and the messages that
+// it pays for to the block if there is enough room in the square for all of
+// them. Returns false if none were added.
+func (sc *shareCounter) addPayForMessage(tx []byte, msgs ...*core.Message) bool {
+	if !sc.addTx(tx) {
+		return false
+	}
+	old := sc.msgShares
+	for _, msg := range msgs {
+		if !sc.addMessage(msg) {
+			sc.txBytes -= delimitedLen(len(tx))
+			sc.msgShares = old
+			return false
+		}
+	}
+	return true
+}
+
+// fits checks if the block data added so far fits in the square
+func (sc *shareCounter) fits() bool {
+	return sc.size() <= sc.squareSize*sc.squareSize
+}
+
+// size returns the number of shares taken up by the block data
+func (sc *shareCounter) size() uint64 {
+	return contiguousShares(sc.txBytes) +
+		contiguousShares(sc.isrBytes) +
+		contiguousShares(sc.evidenceBytes) +
+		sc.msgShares
+}
+
+// messageLess defines the order of messages in the square. Messages are sorted
+// by their namespace, as the rows and columns of the square are namespaced
+// merkle trees, and messages that share a namespace are sorted by their data so
+// that the order does not depend on the order of the txs that pay for them.
+func messageLess(a, b *core.Message) bool {
+	if cmp := bytes.Compare(a.NamespaceId, b.NamespaceId); cmp != 0 {
+		return cmp < 0
+	}
+	return bytes.Compare(a.Data, b.Data) < 0
+}
+
+// contiguousShares returns the number of shares needed to contiguously write
+// the provided number of bytes to a reserved namespace
+func contiguousShares(n uint64) uint64 {
+	return (n + consts.TxShareSize - 1) / consts.TxShareSize
+}
+
+// messageShares returns the number of shares that celestia-core uses to store
+// a message with the provided amount of data
+func messageShares(dataLen int) uint64 {
+	return types.MessageShares(uint64(dataLen))
+}
+
+// delimitedLen returns the length of data after it has been prefixed with its
+// uvarint encoded length
+func delimitedLen(dataLen int) uint64 {
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	return uint64(binary.PutUvarint(lenBuf, uint64(dataLen)) + dataLen)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/pkg/consts"
+	core "github.com/tendermint/tendermint/proto/tendermint/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+func TestShareCounter(t *testing.T) {
+	msgOfShares := func(ns byte, shares int) *core.Message {
+		return &core.Message{
+			NamespaceId: []byte{ns, 0, 0, 0, 0, 0, 0, 0},
+			Data:        make([]byte, shares*consts.MsgShareSize-2),
+		}
+	}
+
+	counter := newShareCounter(4)
+	assert.Equal(t, uint64(0), counter.size())
+
+	// two txs that exactly fill a single share
+	assert.True(t, counter.addTx(make([]byte, consts.TxShareSize-2)))
+	assert.Equal(t, uint64(1), counter.size())
+	assert.True(t, counter.addTx([]byte{}))
+	assert.Equal(t, uint64(2), counter.size())
+
+	// intermediate state roots and evidence start in a new share
+	assert.True(t, counter.addIntermediateStateRoot(make([]byte, 32)))
+	assert.True(t, counter.addEvidence(make([]byte, 100)))
+	assert.Equal(t, uint64(4), counter.size())
+
+	// the message starts in a new share right after the evidence, and the tx
+	// still fits in the last tx share
+	assert.True(t, counter.addPayForMessage(make([]byte, 10), msgOfShares(2, 4)))
+	assert.Equal(t, uint64(8), counter.size())
+
+	// messages are not padded, regardless of their namespace
+	assert.True(t, counter.addPayForMessage(make([]byte, 10), msgOfShares(1, 3)))
+	assert.Equal(t, uint64(11), counter.size())
+
+	// a message that doesn't fit is not added, and neither is its tx
+	assert.False(t, counter.addPayForMessage(make([]byte, 10), msgOfShares(3, 2), msgOfShares(3, 4)))
+	assert.Equal(t, uint64(11), counter.size())
+
+	// a tx that doesn't fit is not added
+	assert.False(t, counter.addTx(make([]byte, 16*consts.TxShareSize)))
+	assert.Equal(t, uint64(11), counter.size())
+
+	// the remaining space can still be used
+	assert.True(t, counter.addMessage(msgOfShares(3, 5)))
+	assert.Equal(t, uint64(16), counter.size())
+}
+
+// TestShareCounterMatchesCore checks that the share counter counts the same
+// number of shares as celestia-core uses for the block data
+func TestShareCounterMatchesCore(t *testing.T) {
+	type test struct {
+		name    string
+		txSizes []int
+		msgSize []int
+	}
+	tests := []test{
+		{"empty", nil, nil},
+		{"single tx", []int{300}, nil},
+		{"single message", []int{10}, []int{1}},
+		{"tx overflowing into the next share", []int{consts.TxShareSize - 1, 2}, []int{consts.MsgShareSize}},
+		{"several messages", []int{500, 200, 1000}, []int{consts.MsgShareSize - 1, 3000, 100, 20000}},
+	}
+	for _, tt := range tests {
+		counter := newShareCounter(consts.MaxSquareSize)
+		data := coretypes.Data{}
+		for i, size := range tt.txSizes {
+			tx := make([]byte, size)
+			tx[0] = byte(i)
+			data.Txs = append(data.Txs, tx)
+			assert.True(t, counter.addTx(tx), tt.name)
+		}
+		for i, size := range tt.msgSize {
+			msg := &core.Message{
+				NamespaceId: []byte{byte(i + 1), 0, 0, 0, 0, 0, 0, 0},
+				Data:        make([]byte, size),
+			}
+			data.Messages.MessagesList = append(data.Messages.MessagesList, coretypes.Message{
+				NamespaceID: msg.NamespaceId,
+				Data:        msg.Data,
+			})
+			assert.True(t, counter.addMessage(msg), tt.name)
+		}
+
+		_, coreShares := data.ComputeShares()
+		assert.Equal(t, uint64(coreShares), counter.size(), tt.name)
+	}
+}
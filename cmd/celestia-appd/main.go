@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/celestiaorg/celestia-app/app"
+	paymentcli "github.com/celestiaorg/celestia-app/x/payment/client/cli"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
@@ -23,6 +24,8 @@ func main() {
 		appBuilder,
 		// this line is used by starport scaffolding # root/arguments
 	)
+	rootCmd.AddCommand(paymentcli.GetOfflineCmd())
+
 	if err := svrcmd.Execute(rootCmd, app.DefaultNodeHome); err != nil {
 		os.Exit(1)
 	}
@@ -0,0 +1,171 @@
+package ante
+
+import (
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// AccountKeeper defines the functionality of the auth module's account keeper
+// that is needed to verify the signatures of MsgWirePayForMessages
+type AccountKeeper interface {
+	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authtypes.AccountI
+}
+
+// ParamsKeeper returns the params of the payment module
+type ParamsKeeper interface {
+	GetParams(ctx sdk.Context) types.Params
+}
+
+// WirePayForMessageDecorator validates txs that contain
+// MsgWirePayForMessages. A MsgWirePayForMessage is only meant to exist in the
+// mempool, so in CheckTx the tx is fully validated to make sure that the block
+// producer can malleate it into a valid tx of MsgPayForMessages for any of the
+// committed square sizes, while in DeliverTx the tx is rejected, since it should
+// have been malleated by the block producer. Conversely, a MsgPayForMessage is
+// only meant to exist in a malleated tx, which wraps the malleated tx along
+// with the hash of the original tx, so a tx containing a MsgPayForMessage is
+// rejected unless its bytes are wrapped as a malleated tx.
+//
+// The decorator must be chained after the default SDK ante handler, so that
+// the signer's pubkey is already set and the tx's own signature is verified.
+type WirePayForMessageDecorator struct {
+	params          ParamsKeeper
+	accounts        AccountKeeper
+	txConfig        sdkclient.TxConfig
+	signModeHandler authsigning.SignModeHandler
+}
+
+func NewWirePayForMessageDecorator(params ParamsKeeper, accounts AccountKeeper, txConfig sdkclient.TxConfig) WirePayForMessageDecorator {
+	return WirePayForMessageDecorator{
+		params:          params,
+		accounts:        accounts,
+		txConfig:        txConfig,
+		signModeHandler: txConfig.SignModeHandler(),
+	}
+}
+
+// AnteHandle fullfills the sdk.AnteDecorator interface
+func (d WirePayForMessageDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if hasPayForMessage(tx) {
+		if _, _, isMalleated := coretypes.UnwrapMalleatedTx(ctx.TxBytes()); !isMalleated {
+			return ctx, types.ErrUnwrappedPFM
+		}
+	}
+
+	wireMsgs := wirePayForMessages(tx)
+	if len(wireMsgs) == 0 {
+		return next(ctx, tx, simulate)
+	}
+
+	if !ctx.IsCheckTx() {
+		return ctx, types.ErrUnmalleatedWirePFM
+	}
+
+	if len(wireMsgs) != len(tx.GetMsgs()) {
+		return ctx, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "MsgWirePayForMessages can't be mixed with other msgs")
+	}
+
+	params := d.params.GetParams(ctx)
+	for _, wireMsg := range wireMsgs {
+		if err := params.ValidateWirePayForMessage(wireMsg); err != nil {
+			return ctx, err
+		}
+	}
+
+	// signatures are not set when simulating a tx
+	if !simulate {
+		if err := d.verifySignatures(ctx, tx, wireMsgs); err != nil {
+			return ctx, err
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// verifySignatures verifies the signature included for each committed square
+// size, by creating the malleated tx for that square size in the same way as
+// the block producer and verifying the signature over it
+func (d WirePayForMessageDecorator) verifySignatures(ctx sdk.Context, tx sdk.Tx, wireMsgs []*types.MsgWirePayForMessage) error {
+	authTx, ok := tx.(authsigning.Tx)
+	if !ok {
+		return sdkerrors.Wrapf(sdkerrors.ErrTxDecode, "unexpected tx type %T", tx)
+	}
+
+	signers := authTx.GetSigners()
+	if len(signers) != 1 {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected a single signer: got %d", len(signers))
+	}
+	acc := d.accounts.GetAccount(ctx, signers[0])
+	if acc == nil || acc.GetPubKey() == nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidPubKey, "no pubkey for signer %s", signers[0])
+	}
+
+	origSigs, err := authTx.GetSignaturesV2()
+	if err != nil {
+		return err
+	}
+	if len(origSigs) != 1 {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected a single signature: got %d", len(origSigs))
+	}
+
+	// the malleated tx uses the same sequence as the original tx
+	signerData := authsigning.SignerData{
+		ChainID:       ctx.ChainID(),
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      origSigs[0].Sequence,
+	}
+
+	for _, commit := range wireMsgs[0].MessageShareCommitment {
+		_, pfms, sig, err := types.ProcessWirePayForMessages(wireMsgs, commit.K)
+		if err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+
+		childTx, err := types.BuildPayForMessageTxFromWireTx(authTx, d.txConfig.NewTxBuilder(), sig, pfms...)
+		if err != nil {
+			return err
+		}
+
+		signBytes, err := d.signModeHandler.GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, signerData, childTx)
+		if err != nil {
+			return err
+		}
+
+		if !acc.GetPubKey().VerifySignature(signBytes, sig) {
+			return sdkerrors.Wrapf(
+				sdkerrors.ErrUnauthorized,
+				"invalid signature for the MsgPayForMessages of square size %d",
+				commit.K,
+			)
+		}
+	}
+
+	return nil
+}
+
+// wirePayForMessages returns the MsgWirePayForMessages in the tx
+func wirePayForMessages(tx sdk.Tx) []*types.MsgWirePayForMessage {
+	var wireMsgs []*types.MsgWirePayForMessage
+	for _, msg := range tx.GetMsgs() {
+		if wireMsg, ok := msg.(*types.MsgWirePayForMessage); ok {
+			wireMsgs = append(wireMsgs, wireMsg)
+		}
+	}
+	return wireMsgs
+}
+
+// hasPayForMessage checks if the tx contains a MsgPayForMessage
+func hasPayForMessage(tx sdk.Tx) bool {
+	for _, msg := range tx.GetMsgs() {
+		if _, ok := msg.(*types.MsgPayForMessage); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package ante
+
+import (
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MessageGasDecorator consumes gas for the messages that are paid for by a tx,
+// so that the gas limit, and therefore the fee, of a tx scales with the amount
+// of data that it posts. Gas is consumed for each byte of a message and for
+// each share commitment, using the gas_per_byte and gas_per_share_commitment
+// params. This applies to the MsgWirePayForMessages of a tx in CheckTx, and to
+// the malleated MsgPayForMessages in DeliverTx, which include a single share
+// commitment each.
+type MessageGasDecorator struct {
+	params ParamsKeeper
+}
+
+func NewMessageGasDecorator(params ParamsKeeper) MessageGasDecorator {
+	return MessageGasDecorator{params: params}
+}
+
+// AnteHandle fullfills the sdk.AnteDecorator interface
+func (d MessageGasDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	var params *types.Params
+	for _, msg := range tx.GetMsgs() {
+		var size uint64
+		var commitments int
+		switch msg := msg.(type) {
+		case *types.MsgWirePayForMessage:
+			size, commitments = msg.MessageSize, len(msg.MessageShareCommitment)
+		case *types.MsgPayForMessage:
+			size, commitments = msg.MessageSize, 1
+		default:
+			continue
+		}
+
+		// only read the params for txs that pay for messages
+		if params == nil {
+			p := d.params.GetParams(ctx)
+			params = &p
+		}
+		ctx.GasMeter().ConsumeGas(params.GasForMessage(size, commitments), "pay for message")
+	}
+
+	return next(ctx, tx, simulate)
+}
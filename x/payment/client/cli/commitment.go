@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+)
+
+// GetOfflineCmd returns the payment commands that don't need a node
+func GetOfflineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Offline %s utilities", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(CmdCommitment())
+
+	return cmd
+}
+
+// MessageCommitments describes the share commitments of a message, as they
+// are included in a MsgWirePayForMessage
+type MessageCommitments struct {
+	NamespaceID string `json:"namespace_id" yaml:"namespace_id"`
+	// MessageSize is the size of the message after it is padded to a multiple
+	// of the share size
+	MessageSize uint64 `json:"message_size" yaml:"message_size"`
+	// Shares is the number of shares used by the message
+	Shares      uint64                 `json:"shares" yaml:"shares"`
+	Commitments []SquareSizeCommitment `json:"commitments" yaml:"commitments"`
+}
+
+// SquareSizeCommitment is the share commitment of a message for a square size
+type SquareSizeCommitment struct {
+	SquareSize      uint64 `json:"square_size" yaml:"square_size"`
+	ShareCommitment string `json:"share_commitment" yaml:"share_commitment"`
+	// SubtreeHeights are the number of shares in each of the subtrees that
+	// the share commitment is created from
+	SubtreeHeights []uint64 `json:"subtree_heights" yaml:"subtree_heights"`
+}
+
+func CmdCommitment() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commitment [hexNamespace] [file]",
+		Short: "Calculate the share commitments of a message without a node",
+		Long: `Calculate the share commitments of a message without a node. The message is
+read from the provided file, or from stdin if no file or "-" is provided, and
+is padded in the same way as when creating a MsgWirePayForMessage. The message
+is streamed, so it is never entirely kept in memory. As the share commitments
+depend on the size of the message, stdin is first copied to a temporary file.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+			clientCtx = clientCtx.WithOutputFormat(output)
+
+			// decode the namespace
+			namespace, err := hex.DecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("failure to decode hex namespace: %w", err)
+			}
+			if len(namespace) != types.NamespaceIDSize {
+				return fmt.Errorf("invalid namespace length: got %d wanted %d", len(namespace), types.NamespaceIDSize)
+			}
+
+			var path string
+			if len(args) == 2 {
+				path = args[1]
+			}
+			in, size, err := openSizedMessage(cmd, path)
+			if err != nil {
+				return fmt.Errorf("failure to read message: %w", err)
+			}
+			defer in.Close()
+
+			msgSize := types.PaddedMessageSize(size)
+			squareSizes, err := readSquareSizes(cmd, int(msgSize))
+			if err != nil {
+				return err
+			}
+
+			// the commitments are built for every square size while the
+			// message is streamed, without keeping it in memory
+			builder := types.NewCommitmentBuilder(namespace, size, squareSizes...)
+			if _, err := io.Copy(builder, in); err != nil {
+				return fmt.Errorf("failure to read message: %w", err)
+			}
+
+			commitments := MessageCommitments{
+				NamespaceID: args[0],
+				MessageSize: msgSize,
+				Shares:      types.MessageShares(msgSize),
+				Commitments: make([]SquareSizeCommitment, len(squareSizes)),
+			}
+			for i, k := range squareSizes {
+				commit, err := builder.Commitment(k)
+				if err != nil {
+					return err
+				}
+				commitments.Commitments[i] = SquareSizeCommitment{
+					SquareSize:      k,
+					ShareCommitment: hex.EncodeToString(commit),
+					SubtreeHeights:  types.MessageSubtreeHeights(k, msgSize),
+				}
+			}
+
+			return clientCtx.PrintObjectLegacy(commitments)
+		},
+	}
+
+	cmd.Flags().StringP(tmcli.OutputFlag, "o", "text", "Output format (text|json)")
+	addSquareSizesFlag(cmd)
+
+	return cmd
+}
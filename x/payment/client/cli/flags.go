@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tendermint/tendermint/pkg/consts"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+)
+
+const (
+	// FlagSquareSizes is the flag used to select the square sizes that share
+	// commitments are created for
+	FlagSquareSizes = "square-sizes"
+	// FlagFile is the flag used to read a message from a file
+	FlagFile = "file"
+	// FlagBase64 is the flag used to read base64 encoded messages
+	FlagBase64 = "base64"
+	// FlagManifest is the flag used to read the messages listed in a manifest
+	FlagManifest = "manifest"
+)
+
+// addSquareSizesFlag adds the square sizes flag to the provided command
+func addSquareSizesFlag(cmd *cobra.Command) {
+	cmd.Flags().UintSlice(
+		FlagSquareSizes,
+		nil,
+		"Comma separated square sizes to create share commitments for. If not set, every square size that fits the message is used",
+	)
+}
+
+// readSquareSizes returns the square sizes selected using the square sizes
+// flag, or every square size that is large enough for a message of the
+// provided size if none are selected
+func readSquareSizes(cmd *cobra.Command, msgSize int) ([]uint64, error) {
+	flagSizes, err := cmd.Flags().GetUintSlice(FlagSquareSizes)
+	if err != nil {
+		return nil, err
+	}
+	if len(flagSizes) == 0 {
+		sizes := types.AllSquareSizes(msgSize)
+		if len(sizes) == 0 {
+			return nil, fmt.Errorf("message of %d bytes does not fit in any square size", msgSize)
+		}
+		return sizes, nil
+	}
+
+	sizes := make([]uint64, len(flagSizes))
+	for i, k := range flagSizes {
+		size := uint64(k)
+		if size < consts.MinSquareSize || size > consts.MaxSquareSize || !types.IsPowerOf2(size) {
+			return nil, fmt.Errorf(
+				"square size %d must be a power of 2 between %d and %d",
+				size,
+				consts.MinSquareSize,
+				consts.MaxSquareSize,
+			)
+		}
+		sizes[i] = size
+	}
+	return sizes, nil
+}
+
+// readMessage reads a message from the provided file, or from stdin if the
+// path is empty or "-"
+func readMessage(cmd *cobra.Command, path string) ([]byte, error) {
+	in, err := openMessage(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	return io.ReadAll(in)
+}
+
+// openMessage opens the provided file, or stdin if the path is empty or "-",
+// to stream a message from it
+func openMessage(cmd *cobra.Command, path string) (io.ReadCloser, error) {
+	if path == "" || path == "-" {
+		return io.NopCloser(cmd.InOrStdin()), nil
+	}
+	return os.Open(path)
+}
+
+// openSizedMessage opens a message in the same way as openMessage, and returns
+// its size along with it. The size of stdin is only known once it is read, so
+// it is copied to a temporary file, which is removed when the message is
+// closed.
+func openSizedMessage(cmd *cobra.Command, path string) (io.ReadCloser, uint64, error) {
+	if path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, 0, err
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		return f, uint64(info.Size()), nil
+	}
+
+	f, err := os.CreateTemp("", "message")
+	if err != nil {
+		return nil, 0, err
+	}
+	tmp := tempFile{f}
+	size, err := io.Copy(f, cmd.InOrStdin())
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmp.Close()
+		return nil, 0, err
+	}
+	return tmp, uint64(size), nil
+}
+
+// tempFile is a temporary file that is removed once it is closed
+type tempFile struct {
+	*os.File
+}
+
+func (f tempFile) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); err == nil {
+		err = rmErr
+	}
+	return err
+}
@@ -24,6 +24,12 @@ func GetQueryCmd(queryRoute string) *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 
+	cmd.AddCommand(CmdQueryParams())
+	cmd.AddCommand(CmdQueryNamespaceStats())
+	cmd.AddCommand(CmdQuerySignerStats())
+	cmd.AddCommand(CmdQueryCommitment())
+	cmd.AddCommand(CmdQueryRejection())
+	cmd.AddCommand(CmdQueryMalleatedTx())
 	// this line is used by starport scaffolding # 1
 
 	return cmd
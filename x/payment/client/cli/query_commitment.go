@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+func CmdQueryCommitment() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "commitment [hexNamespace] [hexMessage] [squareSize]",
+		Short: "Calculate the share commitment of a message for a square size",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			// decode the message
+			message, err := hex.DecodeString(args[1])
+			if err != nil {
+				return fmt.Errorf("failure to decode hex message: %w", err)
+			}
+
+			squareSize, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("failure to parse square size: %w", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Commitment(cmd.Context(), &types.QueryCommitmentRequest{
+				NamespaceId: args[0],
+				Message:     message,
+				SquareSize:  squareSize,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -1,31 +1,84 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/tendermint/tendermint/pkg/consts"
 
 	"github.com/celestiaorg/celestia-app/x/payment/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
+// ManifestEntry is a message listed in a manifest, which is used to pay for
+// multiple messages using a single command
+type ManifestEntry struct {
+	// Namespace is the hex encoded namespace of the message
+	Namespace string `json:"namespace"`
+	// Path is the path of the file containing the message. Relative paths are
+	// resolved from the directory of the manifest.
+	Path string `json:"path"`
+}
+
+// blob is a message that is paid for, along with its namespace
+type blob struct {
+	namespace []byte
+	message   []byte
+}
+
 func CmdWirePayForMessage() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "payForMessage [hexNamespace] [hexMessage]",
 		Short: "Creates a new MsgWirePayForMessage",
-		Args:  cobra.ExactArgs(2),
+		Long: `Creates a new MsgWirePayForMessage. The message can be provided in one of the
+following ways:
+
+- as a hex encoded argument, or a base64 encoded one when using --base64
+- read from a file using --file, or from stdin using --file -, in which case
+  only the namespace is provided as an argument
+- using --manifest, which is the path of a JSON file that lists multiple
+  messages as [{"namespace": "<hex namespace>", "path": "<file>"}]. A tx is
+  submitted for each message, in order, using consecutive sequences. If the
+  node rejects a tx, the command fails without submitting the messages after
+  it, as their sequences would not be valid.
+
+When using --base64, the contents of the files are base64 encoded as well.
+
+By default, a share commitment is created and signed for every square size
+that is large enough for the message, so that the tx can be included in a block
+of any size. Use --square-sizes to only commit to some square sizes.
+
+Use --gas auto to estimate the gas limit of each tx by simulating the
+malleated tx that pays for its message, multiplied by --gas-adjustment. Use
+--dry-run to print the estimates without broadcasting any tx.`,
+		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
+			// the confirmation prompt reads from stdin as well
+			if file, _ := cmd.Flags().GetString(FlagFile); file == "-" && !clientCtx.SkipConfirm {
+				return fmt.Errorf("reading the message from stdin requires --%s", flags.FlagSkipConfirmation)
+			}
+
+			blobs, err := readBlobs(cmd, args)
+			if err != nil {
+				return err
+			}
+
 			// query for account number
 			fromAddress := clientCtx.GetFromAddress()
 			account, err := clientCtx.AccountRetriever.GetAccount(clientCtx, fromAddress)
@@ -39,29 +92,19 @@ func CmdWirePayForMessage() *cobra.Command {
 				return errors.New("no account name provided, please use the --from flag")
 			}
 
-			// decode the namespace
-			namespace, err := hex.DecodeString(args[0])
-			if err != nil {
-				return fmt.Errorf("failure to decode hex namespace: %w", err)
-			}
-
-			// decode the message
-			message, err := hex.DecodeString(args[1])
-			if err != nil {
-				return fmt.Errorf("failure to decode hex message: %w", err)
-			}
-
-			// create the MsgPayForMessage
-			pfmMsg, err := types.NewWirePayForMessage(namespace, message, consts.MaxSquareSize)
-			if err != nil {
-				return err
-			}
-
 			// use the keyring to programmatically sign multiple PayForMessage txs
 			signer := types.NewKeyringSigner(clientCtx.Keyring, accName, clientCtx.ChainID)
-
 			signer.SetAccountNumber(account.GetAccountNumber())
-			signer.SetSequence(account.GetSequence())
+
+			// the txs use consecutive sequences, starting from the sequence
+			// flag if it is set
+			sequence := account.GetSequence()
+			if cmd.Flags().Changed(flags.FlagSequence) {
+				sequence, err = cmd.Flags().GetUint64(flags.FlagSequence)
+				if err != nil {
+					return err
+				}
+			}
 
 			// get and parse the gas limit for this tx
 			rawGasLimit, err := cmd.Flags().GetString(flags.FlagGas)
@@ -83,25 +126,239 @@ func CmdWirePayForMessage() *cobra.Command {
 				return err
 			}
 
-			// sign the  MsgPayForMessage's ShareCommitments
-			err = pfmMsg.SignShareCommitments(
-				signer,
-				types.SetGasLimit(gasSetting.Gas),
-				types.SetFeeAmount(parsedFees),
-			)
-			if err != nil {
-				return err
-			}
+			txf := tx.NewFactoryCLI(clientCtx, cmd.Flags()).
+				WithAccountNumber(account.GetAccountNumber())
+			for i, b := range blobs {
+				// create the MsgPayForMessage, committing to the selected square
+				// sizes, or to every square size that the block producer could
+				// select
+				squareSizes, err := readSquareSizes(cmd, len(b.message))
+				if err != nil {
+					return err
+				}
+				pfmMsg, err := types.NewWirePayForMessage(b.namespace, b.message, squareSizes...)
+				if err != nil {
+					return err
+				}
 
-			// run message checks
-			if err = pfmMsg.ValidateBasic(); err != nil {
-				return err
+				signer.SetSequence(sequence)
+
+				// simulating the wire tx doesn't reflect the cost of the
+				// malleated tx, so the gas is estimated by the signer instead
+				gas := gasSetting.Gas
+				if gasSetting.Simulate || clientCtx.Simulate {
+					gas, err = signer.EstimatePayForMessageGas(
+						cmd.Context(),
+						clientCtx,
+						[]*types.MsgWirePayForMessage{pfmMsg},
+						txf.GasAdjustment(),
+						types.SetFeeAmount(parsedFees),
+					)
+					if err != nil {
+						return err
+					}
+					_, _ = fmt.Fprintf(os.Stderr, "%s\n", tx.GasEstimateResponse{GasEstimate: gas})
+					if clientCtx.Simulate {
+						sequence++
+						continue
+					}
+				}
+
+				// sign the  MsgPayForMessage's ShareCommitments
+				err = pfmMsg.SignShareCommitments(
+					signer,
+					types.SetGasLimit(gas),
+					types.SetFeeAmount(parsedFees),
+				)
+				if err != nil {
+					return err
+				}
+
+				// run message checks
+				if err = pfmMsg.ValidateBasic(); err != nil {
+					return err
+				}
+				blobTxf := txf.WithSequence(sequence).WithGas(gas).WithSimulateAndExecute(false)
+				if clientCtx.GenerateOnly {
+					if err = tx.GenerateOrBroadcastTxWithFactory(clientCtx, blobTxf, pfmMsg); err != nil {
+						return err
+					}
+					sequence++
+					continue
+				}
+				res, err := broadcastTx(clientCtx, blobTxf, pfmMsg)
+				if err != nil {
+					return err
+				}
+				// the sequence is only used by a tx that the node accepted, so
+				// the following txs can't be submitted using consecutive
+				// sequences once a tx is rejected or cancelled
+				if res == nil {
+					return nil
+				}
+				if res.Code != 0 {
+					return fmt.Errorf(
+						"tx %s paying for message %d was rejected, the remaining %d messages were not submitted: %w",
+						res.TxHash,
+						i,
+						len(blobs)-i-1,
+						sdkerrors.ABCIError(res.Codespace, res.Code, res.RawLog),
+					)
+				}
+				sequence++
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), pfmMsg)
+			return nil
 		},
 	}
 
 	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().String(FlagFile, "", "Read the message from a file, or from stdin if set to -")
+	cmd.Flags().Bool(FlagBase64, false, "The message is base64 encoded instead of hex encoded")
+	cmd.Flags().String(FlagManifest, "", "Pay for each of the messages listed in a JSON manifest")
+	addSquareSizesFlag(cmd)
 
 	return cmd
 }
+
+// broadcastTx signs and broadcasts the tx like
+// tx.GenerateOrBroadcastTxWithFactory, and returns the response of the node,
+// which is printed using the output format of the client context. A nil
+// response is returned if the tx was not confirmed. A tx rejected by CheckTx
+// doesn't cause an error, so the code of the response has to be checked.
+func broadcastTx(clientCtx client.Context, txf tx.Factory, msg sdk.Msg) (*sdk.TxResponse, error) {
+	out := &bytes.Buffer{}
+	jsonCtx := clientCtx.WithOutput(out).WithOutputFormat("json")
+	if err := tx.GenerateOrBroadcastTxWithFactory(jsonCtx, txf, msg); err != nil {
+		return nil, err
+	}
+	if out.Len() == 0 {
+		return nil, nil
+	}
+	var res sdk.TxResponse
+	if err := clientCtx.Codec.UnmarshalJSON(out.Bytes(), &res); err != nil {
+		return nil, err
+	}
+	return &res, clientCtx.PrintProto(&res)
+}
+
+// readBlobs reads the messages to pay for using the arguments and the flags
+// of the payForMessage command
+func readBlobs(cmd *cobra.Command, args []string) ([]blob, error) {
+	file, err := cmd.Flags().GetString(FlagFile)
+	if err != nil {
+		return nil, err
+	}
+	isBase64, err := cmd.Flags().GetBool(FlagBase64)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := cmd.Flags().GetString(FlagManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case manifest != "":
+		if len(args) != 0 || cmd.Flags().Changed(FlagFile) {
+			return nil, fmt.Errorf("--%s can't be used with arguments or --%s", FlagManifest, FlagFile)
+		}
+		return readManifest(manifest, isBase64)
+
+	case cmd.Flags().Changed(FlagFile):
+		if len(args) != 1 {
+			return nil, fmt.Errorf("expected only the namespace as an argument when using --%s", FlagFile)
+		}
+		namespace, err := decodeNamespace(args[0])
+		if err != nil {
+			return nil, err
+		}
+		message, err := readMessage(cmd, file)
+		if err != nil {
+			return nil, fmt.Errorf("failure to read message: %w", err)
+		}
+		message, err = decodeFileMessage(message, isBase64)
+		if err != nil {
+			return nil, err
+		}
+		return []blob{{namespace: namespace, message: message}}, nil
+
+	default:
+		if len(args) != 2 {
+			return nil, errors.New("expected the namespace and the message as arguments")
+		}
+		namespace, err := decodeNamespace(args[0])
+		if err != nil {
+			return nil, err
+		}
+		var message []byte
+		if isBase64 {
+			message, err = base64.StdEncoding.DecodeString(args[1])
+		} else {
+			message, err = hex.DecodeString(args[1])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failure to decode message: %w", err)
+		}
+		return []blob{{namespace: namespace, message: message}}, nil
+	}
+}
+
+// readManifest reads the messages listed in a manifest
+func readManifest(path string, isBase64 bool) ([]blob, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failure to read manifest: %w", err)
+	}
+	var entries []ManifestEntry
+	if err := json.Unmarshal(bz, &entries); err != nil {
+		return nil, fmt.Errorf("failure to decode manifest: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("manifest does not list any messages")
+	}
+
+	dir := filepath.Dir(path)
+	blobs := make([]blob, len(entries))
+	for i, entry := range entries {
+		namespace, err := decodeNamespace(entry.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("manifest entry %d: %w", i, err)
+		}
+		messagePath := entry.Path
+		if !filepath.IsAbs(messagePath) {
+			messagePath = filepath.Join(dir, messagePath)
+		}
+		message, err := os.ReadFile(messagePath)
+		if err != nil {
+			return nil, fmt.Errorf("manifest entry %d: failure to read message: %w", i, err)
+		}
+		message, err = decodeFileMessage(message, isBase64)
+		if err != nil {
+			return nil, fmt.Errorf("manifest entry %d: %w", i, err)
+		}
+		blobs[i] = blob{namespace: namespace, message: message}
+	}
+	return blobs, nil
+}
+
+// decodeNamespace decodes a hex encoded namespace
+func decodeNamespace(namespace string) ([]byte, error) {
+	bz, err := hex.DecodeString(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failure to decode hex namespace: %w", err)
+	}
+	return bz, nil
+}
+
+// decodeFileMessage decodes the contents of a file containing a message, which
+// are either the raw message or the base64 encoded message
+func decodeFileMessage(contents []byte, isBase64 bool) ([]byte, error) {
+	if !isBase64 {
+		return contents, nil
+	}
+	message, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(contents)))
+	if err != nil {
+		return nil, fmt.Errorf("failure to decode base64 message: %w", err)
+	}
+	return message, nil
+}
@@ -1,22 +1,38 @@
 package testutil
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/suite"
 
 	clitestutil "github.com/cosmos/cosmos-sdk/testutil/cli"
 	cosmosnet "github.com/cosmos/cosmos-sdk/testutil/network"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 
 	"github.com/celestiaorg/celestia-app/testutil/network"
 	paycli "github.com/celestiaorg/celestia-app/x/payment/client/cli"
+	paytypes "github.com/celestiaorg/celestia-app/x/payment/types"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"google.golang.org/grpc"
 )
 
 // username is used to create a funded genesis account under this name
@@ -103,10 +119,24 @@ func (s *IntegrationTestSuite) TestSubmitWirePayForMessage() {
 				require.Equal(tc.expectedCode, txResp.Code,
 					"test: %s, output\n:", tc.name, out.String())
 
-				events := txResp.Logs[0].GetEvents()
-				for i := 0; i < len(events); i++ {
-					s.Equal("/payment.MsgPayForMessage", events[i].GetAttributes()[0].Value)
+				// the tx is malleated into a MsgPayForMessage, which burns the
+				// payment for the message
+				var actions []string
+				burned := false
+				for _, event := range txResp.Logs[0].GetEvents() {
+					switch event.Type {
+					case sdk.EventTypeMessage:
+						for _, attr := range event.Attributes {
+							if attr.Key == sdk.AttributeKeyAction {
+								actions = append(actions, attr.Value)
+							}
+						}
+					case banktypes.EventTypeCoinBurn:
+						burned = true
+					}
 				}
+				s.Contains(actions, "/payment.MsgPayForMessage")
+				s.True(burned)
 
 				// wait for the tx to be indexed
 				time.Sleep(time.Second * 3)
@@ -118,11 +148,478 @@ func (s *IntegrationTestSuite) TestSubmitWirePayForMessage() {
 
 				var result sdk.TxResponse
 				s.Require().NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &result))
+
+				// the message is added to the totals of its namespace
+				out, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdQueryNamespaceStats(), []string{hexNS, "--output=json"})
+				require.NoError(err)
+
+				var stats paytypes.NamespaceStats
+				require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &stats))
+				s.Equal(uint64(1), stats.TotalMessages)
+				s.Equal(uint64(paytypes.ShareSize), stats.TotalBytes)
+				s.Equal(txResp.Height, stats.LastHeight)
+
+				// the tx can be found using the namespace of its message
+				query := fmt.Sprintf("%s.%s=%s", paytypes.EventTypePayForMessage, paytypes.AttributeKeyNamespace, hexNS)
+				out, err = clitestutil.ExecTestCLICmd(clientCtx, authcmd.QueryTxsByEventsCmd(), []string{"--events", query, "--output=json"})
+				require.NoError(err)
+
+				var search sdk.SearchTxsResult
+				require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &search))
+				require.Len(search.Txs, 1)
+				s.Equal(txResp.Height, search.Txs[0].Height)
+
+				// the malleated tx can be found using the original tx's hash
+				out, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdQueryMalleatedTx(), []string{txResp.TxHash, "--output=json"})
+				require.NoError(err)
+
+				var malleatedTx paytypes.MalleatedTx
+				require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &malleatedTx))
+				s.Equal(txResp.TxHash, malleatedTx.ParentHash)
+				s.Equal(txResp.Height, malleatedTx.Height)
 			}
 		})
 	}
 }
 
+func (s *IntegrationTestSuite) TestSubmitMessagesFromFiles() {
+	require := s.Require()
+	val := s.network.Validators[0]
+	clientCtx := val.ClientCtx
+	dir := s.T().TempDir()
+
+	txFlags := []string{
+		fmt.Sprintf("--from=%s", username),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(2))).String()),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+	}
+
+	// submit runs the payForMessage command and returns the response of each
+	// of the submitted txs
+	submit := func(stdin []byte, args ...string) []sdk.TxResponse {
+		out, err := execWithStdin(clientCtx, paycli.CmdWirePayForMessage(), stdin, append(args, txFlags...))
+		require.NoError(err, out.String())
+
+		var responses []sdk.TxResponse
+		decoder := json.NewDecoder(bytes.NewReader(out.Bytes()))
+		for decoder.More() {
+			var raw json.RawMessage
+			require.NoError(decoder.Decode(&raw))
+			var res sdk.TxResponse
+			require.NoError(clientCtx.Codec.UnmarshalJSON(raw, &res))
+			require.Equal(uint32(0), res.Code, res.RawLog)
+			responses = append(responses, res)
+		}
+		return responses
+	}
+
+	// namespaceMessages returns the number of messages paid for in a namespace
+	namespaceMessages := func(namespace string) uint64 {
+		out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdQueryNamespaceStats(), []string{namespace, "--output=json"})
+		require.NoError(err)
+		var stats paytypes.NamespaceStats
+		require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &stats))
+		return stats.TotalMessages
+	}
+
+	message := bytes.Repeat([]byte{7}, 2*paytypes.ShareSize)
+	messagePath := filepath.Join(dir, "message")
+	require.NoError(os.WriteFile(messagePath, message, 0600))
+	base64Path := filepath.Join(dir, "message.b64")
+	require.NoError(os.WriteFile(base64Path, []byte(base64.StdEncoding.EncodeToString(message)+"\n"), 0600))
+
+	s.Len(submit(nil, "1111111111111111", fmt.Sprintf("--%s=%s", paycli.FlagFile, messagePath)), 1)
+	s.Equal(uint64(1), namespaceMessages("1111111111111111"))
+
+	s.Len(submit(message, "2222222222222222", fmt.Sprintf("--%s=-", paycli.FlagFile)), 1)
+	s.Equal(uint64(1), namespaceMessages("2222222222222222"))
+
+	s.Len(submit(nil, "3333333333333333", base64.StdEncoding.EncodeToString(message), fmt.Sprintf("--%s", paycli.FlagBase64)), 1)
+	s.Equal(uint64(1), namespaceMessages("3333333333333333"))
+
+	// the messages of a manifest are submitted using consecutive sequences
+	manifest, err := json.Marshal([]paycli.ManifestEntry{
+		{Namespace: "4444444444444444", Path: "message.b64"},
+		{Namespace: "5555555555555555", Path: base64Path},
+	})
+	require.NoError(err)
+	manifestPath := filepath.Join(dir, "manifest.json")
+	require.NoError(os.WriteFile(manifestPath, manifest, 0600))
+
+	s.Len(submit(nil, fmt.Sprintf("--%s=%s", paycli.FlagManifest, manifestPath), fmt.Sprintf("--%s", paycli.FlagBase64)), 2)
+	s.Equal(uint64(1), namespaceMessages("4444444444444444"))
+	s.Equal(uint64(1), namespaceMessages("5555555555555555"))
+
+	// a large message runs out of gas in CheckTx, which stops the manifest
+	// without submitting the messages after it
+	largePath := filepath.Join(dir, "large.b64")
+	largeMessage := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{8}, 64*1024))
+	require.NoError(os.WriteFile(largePath, []byte(largeMessage), 0600))
+	manifest, err = json.Marshal([]paycli.ManifestEntry{
+		{Namespace: "6161616161616161", Path: base64Path},
+		{Namespace: "7171717171717171", Path: largePath},
+		{Namespace: "8181818181818181", Path: base64Path},
+	})
+	require.NoError(err)
+	require.NoError(os.WriteFile(manifestPath, manifest, 0600))
+
+	args := []string{fmt.Sprintf("--%s=%s", paycli.FlagManifest, manifestPath), fmt.Sprintf("--%s", paycli.FlagBase64)}
+	out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdWirePayForMessage(), append(args, txFlags...))
+	require.Error(err)
+	s.Contains(err.Error(), "paying for message 1 was rejected")
+	s.Contains(err.Error(), "out of gas")
+	s.Contains(out.String(), `"code":11`)
+	s.Equal(uint64(1), namespaceMessages("6161616161616161"))
+	s.Equal(uint64(0), namespaceMessages("7171717171717171"))
+	s.Equal(uint64(0), namespaceMessages("8181818181818181"))
+
+	// the following txs use the next sequence
+	s.Len(submit(nil, "9191919191919191", fmt.Sprintf("--%s=%s", paycli.FlagFile, messagePath)), 1)
+}
+
+func (s *IntegrationTestSuite) TestSquareSizes() {
+	require := s.Require()
+	clientCtx := s.network.Validators[0].ClientCtx
+	hexMsg := "0204033704032c0b162109000908094d425837422c2116"
+
+	out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdWirePayForMessage(), []string{
+		"6666666666666666",
+		hexMsg,
+		fmt.Sprintf("--%s=2,4,8,16", paycli.FlagSquareSizes),
+		fmt.Sprintf("--from=%s", username),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(2))).String()),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+	})
+	require.NoError(err, out.String())
+
+	var txResp sdk.TxResponse
+	require.NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &txResp))
+	require.Equal(uint32(0), txResp.Code, txResp.RawLog)
+
+	// wait for the tx to be indexed
+	require.NoError(s.network.WaitForNextBlock())
+
+	// the malleated tx is signed for one of the selected square sizes
+	res, err := authtx.QueryTx(clientCtx, txResp.TxHash)
+	require.NoError(err)
+	tx, ok := res.Tx.GetCachedValue().(sdk.Tx)
+	require.True(ok)
+	require.Len(tx.GetMsgs(), 1)
+	message, err := hex.DecodeString(hexMsg)
+	require.NoError(err)
+	wireMsg, err := paytypes.NewWirePayForMessage(bytes.Repeat([]byte{0x66}, 8), message, 2, 4, 8, 16)
+	require.NoError(err)
+	var commitments [][]byte
+	for _, commit := range wireMsg.MessageShareCommitment {
+		commitments = append(commitments, commit.ShareCommitment)
+	}
+	s.Contains(commitments, tx.GetMsgs()[0].(*paytypes.MsgPayForMessage).MessageShareCommitment)
+
+	// square sizes must be powers of two
+	_, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdWirePayForMessage(), []string{
+		"6666666666666666",
+		hexMsg,
+		fmt.Sprintf("--%s=6", paycli.FlagSquareSizes),
+		fmt.Sprintf("--from=%s", username),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+	})
+	s.Error(err)
+}
+
+func (s *IntegrationTestSuite) TestCommitment() {
+	require := s.Require()
+	clientCtx := s.network.Validators[0].ClientCtx
+
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 2*paytypes.ShareSize+1)
+	path := filepath.Join(s.T().TempDir(), "message")
+	require.NoError(os.WriteFile(path, message, 0600))
+
+	out, err := clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdCommitment(), []string{
+		hex.EncodeToString(namespace),
+		path,
+		fmt.Sprintf("--%s=4,8", paycli.FlagSquareSizes),
+		"--output=json",
+	})
+	require.NoError(err, out.String())
+
+	var commitments paycli.MessageCommitments
+	require.NoError(clientCtx.LegacyAmino.UnmarshalJSON(out.Bytes(), &commitments))
+	s.Equal(uint64(3*paytypes.ShareSize), commitments.MessageSize)
+	s.Equal(paytypes.MessageShares(3*paytypes.ShareSize), commitments.Shares)
+	require.Len(commitments.Commitments, 2)
+	for i, k := range []uint64{4, 8} {
+		expected, err := paytypes.CreateCommitment(k, namespace, message)
+		require.NoError(err)
+		s.Equal(k, commitments.Commitments[i].SquareSize)
+		s.Equal(hex.EncodeToString(expected), commitments.Commitments[i].ShareCommitment)
+		s.Equal(paytypes.MessageSubtreeHeights(k, 3*paytypes.ShareSize), commitments.Commitments[i].SubtreeHeights)
+	}
+
+	// the same commitments are created when the message is read from stdin
+	stdinOut, err := execWithStdin(clientCtx, paycli.CmdCommitment(), message, []string{
+		hex.EncodeToString(namespace),
+		fmt.Sprintf("--%s=4,8", paycli.FlagSquareSizes),
+		"--output=json",
+	})
+	require.NoError(err, stdinOut.String())
+	s.Equal(out.String(), stdinOut.String())
+
+	// square sizes that are not powers of two are rejected
+	_, err = clitestutil.ExecTestCLICmd(clientCtx, paycli.CmdCommitment(), []string{
+		hex.EncodeToString(namespace),
+		path,
+		fmt.Sprintf("--%s=3", paycli.FlagSquareSizes),
+	})
+	s.Error(err)
+}
+
+func (s *IntegrationTestSuite) TestKeyringSignerSequence() {
+	require := s.Require()
+	val := s.network.Validators[0]
+
+	conn, err := grpc.Dial(val.AppConfig.GRPC.Address, grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+
+	signer := paytypes.NewKeyringSigner(s.kr, username, s.cfg.ChainID)
+	require.NoError(signer.QueryAccountNumber(context.Background(), conn))
+	sequence := signer.GetSequence()
+
+	options := []paytypes.TxBuilderOption{
+		paytypes.SetGasLimit(200000),
+		paytypes.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(2)))),
+	}
+	namespace := []byte{2, 2, 2, 2, 2, 2, 2, 2}
+	broadcast := func(message []byte) *sdk.TxResponse {
+		msg, err := paytypes.NewWirePayForMessage(namespace, message, paytypes.AllSquareSizes(len(message))...)
+		require.NoError(err)
+		resp, err := signer.BroadcastMsgs(context.Background(), conn, tx.BroadcastMode_BROADCAST_MODE_BLOCK, []sdk.Msg{msg}, options...)
+		require.NoError(err)
+		return resp.TxResponse
+	}
+
+	// the tx is resubmitted with the expected sequence after a mismatch
+	signer.SetSequence(sequence + 5)
+	txResp := broadcast([]byte{1})
+	require.Equal(uint32(0), txResp.Code, txResp.RawLog)
+	s.Equal(sequence+1, signer.GetSequence())
+
+	// the sequence is incremented after each successful tx
+	txResp = broadcast([]byte{2})
+	require.Equal(uint32(0), txResp.Code, txResp.RawLog)
+	s.Equal(sequence+2, signer.GetSequence())
+}
+
+func (s *IntegrationTestSuite) TestBlobClient() {
+	require := s.Require()
+	val := s.network.Validators[0]
+
+	conn, err := grpc.Dial(val.AppConfig.GRPC.Address, grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+
+	client := paytypes.NewBlobClient(paytypes.NewKeyringSigner(s.kr, username, s.cfg.ChainID), conn)
+	client.SetPollInterval(200 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	namespace := []byte{3, 3, 3, 3, 3, 3, 3, 3}
+	message := bytes.Repeat([]byte{3}, 1000)
+	result, err := client.SubmitPayForMessage(
+		ctx,
+		namespace,
+		message,
+		paytypes.SetGasLimit(200000),
+		paytypes.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(2)))),
+	)
+	require.NoError(err)
+	s.Greater(result.Height, int64(0))
+	s.NotEqual(result.ParentHash, result.TxHash)
+
+	commitment, err := paytypes.CreateCommitment(result.SquareSize, namespace, message)
+	require.NoError(err)
+	s.Equal(commitment, result.ShareCommitment)
+
+	// the malleated tx is indexed by the hash of the tx that was broadcast
+	out, err := clitestutil.ExecTestCLICmd(val.ClientCtx, paycli.CmdQueryMalleatedTx(), []string{result.ParentHash, "--output=json"})
+	require.NoError(err)
+	var malleatedTx paytypes.MalleatedTx
+	require.NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &malleatedTx))
+	s.Equal(result.TxHash, malleatedTx.Hash)
+	s.Equal(result.Height, malleatedTx.Height)
+
+	// the context's deadline is respected while waiting for inclusion
+	expired, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	_, err = client.SubmitPayForMessage(expired, namespace, message)
+	s.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func (s *IntegrationTestSuite) TestEstimatePayForMessageGas() {
+	require := s.Require()
+	val := s.network.Validators[0]
+
+	conn, err := grpc.Dial(val.AppConfig.GRPC.Address, grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+
+	signer := paytypes.NewKeyringSigner(s.kr, username, s.cfg.ChainID)
+	require.NoError(signer.QueryAccountNumber(context.Background(), conn))
+
+	// the wire tx of a large message consumes more gas in CheckTx than the
+	// default gas limit, and the fee must cover the min gas price
+	message := bytes.Repeat([]byte{4}, 30000)
+	msg, err := paytypes.NewWirePayForMessage([]byte{4, 4, 4, 4, 4, 4, 4, 4}, message, paytypes.AllSquareSizes(len(message))...)
+	require.NoError(err)
+	fees := paytypes.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))))
+
+	gas, err := signer.EstimatePayForMessageGas(context.Background(), conn, []*paytypes.MsgWirePayForMessage{msg}, paytypes.DefaultGasAdjustment, fees)
+	require.NoError(err)
+	s.Greater(gas, uint64(flags.DefaultGasLimit))
+	// the msg is not signed by the estimate
+	for _, commit := range msg.MessageShareCommitment {
+		s.Empty(commit.Signature)
+	}
+
+	resp, err := signer.BroadcastMsgs(context.Background(), conn, tx.BroadcastMode_BROADCAST_MODE_BLOCK, []sdk.Msg{msg}, paytypes.SetGasLimit(gas), fees)
+	require.NoError(err)
+	require.Equal(uint32(0), resp.TxResponse.Code, resp.TxResponse.RawLog)
+	s.Equal(int64(gas), resp.TxResponse.GasWanted)
+	s.LessOrEqual(resp.TxResponse.GasUsed, resp.TxResponse.GasWanted)
+}
+
+func (s *IntegrationTestSuite) TestWirePayForMessageGasAuto() {
+	require := s.Require()
+	val := s.network.Validators[0]
+
+	args := []string{
+		"0505050505050505",
+		hex.EncodeToString(bytes.Repeat([]byte{5}, 30000)),
+		fmt.Sprintf("--from=%s", username),
+		fmt.Sprintf("--%s=%s", flags.FlagGas, flags.GasFlagAuto),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))).String()),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+	}
+	out, err := clitestutil.ExecTestCLICmd(val.ClientCtx, paycli.CmdWirePayForMessage(), args)
+	require.NoError(err, out.String())
+
+	var txResp sdk.TxResponse
+	require.NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &txResp), out.String())
+	require.Equal(uint32(0), txResp.Code, txResp.RawLog)
+	s.Greater(txResp.GasWanted, int64(flags.DefaultGasLimit))
+	s.LessOrEqual(txResp.GasUsed, txResp.GasWanted)
+}
+
+func (s *IntegrationTestSuite) TestSubmitterPool() {
+	require := s.Require()
+	val := s.network.Validators[0]
+
+	conn, err := grpc.Dial(val.AppConfig.GRPC.Address, grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	names, err := paytypes.CreateSubmitterKeys(s.kr, "submitter", 3)
+	require.NoError(err)
+	signers := make([]*paytypes.KeyringSigner, len(names))
+	addresses := make([]sdk.AccAddress, len(names))
+	for i, name := range names {
+		signers[i] = paytypes.NewKeyringSigner(s.kr, name, s.cfg.ChainID)
+		addresses[i] = signers[i].GetSignerInfo().GetAddress()
+	}
+
+	// the accounts are funded by the master account, which also pays their
+	// fees
+	master := paytypes.NewKeyringSigner(s.kr, username, s.cfg.ChainID)
+	masterOptions := []paytypes.TxBuilderOption{
+		paytypes.SetGasLimit(200000),
+		paytypes.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10)))),
+	}
+	amount := sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(1000000)))
+	require.NoError(paytypes.FundAccounts(ctx, conn, master, addresses, amount, masterOptions...))
+	require.NoError(paytypes.GrantFeeAllowances(ctx, conn, master, addresses, &feegrant.BasicAllowance{}, masterOptions...))
+
+	pool, err := paytypes.NewSubmitterPool(
+		signers,
+		conn,
+		2,
+		paytypes.SetGasLimit(200000),
+		paytypes.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10)))),
+		paytypes.SetFeeGranter(master.GetSignerInfo().GetAddress()),
+	)
+	require.NoError(err)
+	pool.SetPollInterval(200 * time.Millisecond)
+	s.Equal(addresses, pool.Addresses())
+
+	namespace := []byte{6, 6, 6, 6, 6, 6, 6, 6}
+	results := make([]*paytypes.PayForMessageResult, 6)
+	errs := make([]error, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = pool.Submit(ctx, namespace, bytes.Repeat([]byte{byte(i)}, 100))
+		}(i)
+	}
+	wg.Wait()
+	pool.Close()
+
+	signerAddresses := make(map[string]bool)
+	for i, result := range results {
+		require.NoError(errs[i])
+		s.Greater(result.Height, int64(0))
+		signerAddresses[result.Signer] = true
+	}
+	for address := range signerAddresses {
+		s.Contains(names, s.keyName(address))
+	}
+
+	stats := pool.Stats()
+	s.Equal(0, stats.Queued)
+	s.Equal(0, stats.InFlight)
+	submitted := uint64(0)
+	for _, account := range stats.Accounts {
+		submitted += account.Submitted
+		s.Zero(account.Failed)
+	}
+	s.Equal(uint64(len(results)), submitted)
+
+	_, err = pool.Submit(ctx, namespace, []byte{1})
+	s.ErrorIs(err, paytypes.ErrSubmitterPoolClosed)
+}
+
+// keyName returns the name of the key of the bech32 encoded address
+func (s *IntegrationTestSuite) keyName(address string) string {
+	addr, err := sdk.AccAddressFromBech32(address)
+	s.Require().NoError(err)
+	info, err := s.kr.KeyByAddress(addr)
+	s.Require().NoError(err)
+	return info.GetName()
+}
+
+// execWithStdin executes the command like clitestutil.ExecTestCLICmd, which
+// replaces the input of the command with an empty reader, but reads stdin from
+// the provided bytes
+func execWithStdin(clientCtx client.Context, cmd *cobra.Command, stdin []byte, args []string) (*bytes.Buffer, error) {
+	out := &bytes.Buffer{}
+	cmd.SetArgs(args)
+	cmd.SetIn(bytes.NewReader(stdin))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	clientCtx = clientCtx.WithOutput(out)
+	ctx := context.WithValue(context.Background(), client.ClientContextKey, &clientCtx)
+	return out, cmd.ExecuteContext(ctx)
+}
+
 func TestIntegrationTestSuite(t *testing.T) {
 	suite.Run(t, NewIntegrationTestSuite(network.DefaultConfig()))
 }
@@ -0,0 +1,121 @@
+package inclusion
+
+import (
+	"bytes"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+// newRowHasher returns the hasher of the namespaced merkle trees of the rows of
+// the extended data square, which are built in the same way as celestia-core's
+// ErasuredNamespacedMerkleTree
+func newRowHasher() *nmt.Hasher {
+	return nmt.NewNmtHasher(consts.NewBaseHashFunc(), consts.NamespaceSize, true)
+}
+
+// rowLeafHash returns the hash of the leaf of a share at the provided column of
+// a row. Shares in the original data square are namespaced by their first
+// bytes, while parity shares use the parity namespace.
+func rowLeafHash(hasher *nmt.Hasher, squareSize, col uint64, share []byte) []byte {
+	nid := share[:consts.NamespaceSize]
+	if col >= squareSize {
+		nid = consts.ParitySharesNamespaceID
+	}
+	leaf := make([]byte, 0, consts.NamespaceSize+len(share))
+	leaf = append(append(leaf, nid...), share...)
+	return hasher.HashLeaf(leaf)
+}
+
+// rowLeafHashes returns the hashes of the leaves of the tree of a row of the
+// extended data square
+func rowLeafHashes(hasher *nmt.Hasher, squareSize uint64, row [][]byte) [][]byte {
+	leafHashes := make([][]byte, len(row))
+	for col, share := range row {
+		leafHashes[col] = rowLeafHash(hasher, squareSize, uint64(col), share)
+	}
+	return leafHashes
+}
+
+// rangeRoot computes the root of the subtree of the provided leaf hashes,
+// splitting the leaves in the same way as the nmt
+func rangeRoot(hasher *nmt.Hasher, leafHashes [][]byte) []byte {
+	switch len(leafHashes) {
+	case 0:
+		return hasher.EmptyRoot()
+	case 1:
+		return leafHashes[0]
+	default:
+		split := splitPoint(uint64(len(leafHashes)))
+		return hasher.HashNode(rangeRoot(hasher, leafHashes[:split]), rangeRoot(hasher, leafHashes[split:]))
+	}
+}
+
+// proveRange returns the nodes of the nmt range proof of the leaves in the
+// range [start, end), which are the roots of the largest subtrees outside of
+// the range, from left to right. For the trees of rows, whose width is a power
+// of two, these are the nodes that the nmt creates for its own range proofs.
+func proveRange(hasher *nmt.Hasher, leafHashes [][]byte, start, end uint64) [][]byte {
+	var nodes [][]byte
+	var walk func(lo, hi uint64)
+	walk = func(lo, hi uint64) {
+		switch {
+		case hi <= start || lo >= end:
+			nodes = append(nodes, rangeRoot(hasher, leafHashes[lo:hi]))
+		case start <= lo && hi <= end:
+		default:
+			split := lo + splitPoint(hi-lo)
+			walk(lo, split)
+			walk(split, hi)
+		}
+	}
+	walk(0, uint64(len(leafHashes)))
+	return nodes
+}
+
+// verifyRange checks the nmt range proof of the leaves in the range
+// [start, end) of a tree with the provided number of leaves against its root.
+// Unlike the namespace proofs of the nmt, it does not check that the range
+// contains all the leaves of a namespace.
+func verifyRange(hasher *nmt.Hasher, leafCount, start, end uint64, leafHashes, nodes [][]byte, root []byte) bool {
+	if start >= end || end > leafCount || uint64(len(leafHashes)) != end-start {
+		return false
+	}
+	valid := true
+	var walk func(lo, hi uint64) []byte
+	walk = func(lo, hi uint64) []byte {
+		switch {
+		case !valid:
+			return nil
+		case hi <= start || lo >= end:
+			if len(nodes) == 0 || len(nodes[0]) != 2*consts.NamespaceSize+hasher.Size() {
+				valid = false
+				return nil
+			}
+			node := nodes[0]
+			nodes = nodes[1:]
+			return node
+		case start <= lo && hi <= end:
+			return rangeRoot(hasher, leafHashes[lo-start:hi-start])
+		default:
+			split := lo + splitPoint(hi-lo)
+			left, right := walk(lo, split), walk(split, hi)
+			if !valid {
+				return nil
+			}
+			return hasher.HashNode(left, right)
+		}
+	}
+	got := walk(0, leafCount)
+	return valid && len(nodes) == 0 && bytes.Equal(got, root)
+}
+
+// splitPoint returns the largest power of two that is less than the number of
+// leaves, which is where the nmt splits a tree into its subtrees
+func splitPoint(leafCount uint64) uint64 {
+	split := uint64(1)
+	for split*2 < leafCount {
+		split *= 2
+	}
+	return split
+}
@@ -0,0 +1,272 @@
+// Package inclusion creates and verifies proofs that a message paid for by a
+// MsgPayForMessage is included in the data root of a block.
+//
+// A MessageProof is a data root inclusion proof of the shares that
+// celestia-core splits the message into. For each row of the extended data
+// square that the message is laid out in, it proves the range of shares of the
+// message against the row root with an nmt range proof, and proves the row
+// root against the data root. Both proofs can be created and verified without
+// a running node, as long as the block data is known.
+//
+// The subtree roots of a share commitment are proven through the shares. The
+// leaves of the subtrees are the shares of the message prefixed with its
+// namespace, which are the leaves of the row trees that the range proofs prove.
+// celestia-core does not align a message to the subtree boundaries, so the
+// subtree roots are generally not nodes of the row trees, and are instead
+// computed from the proven shares. This proves the shares against the row roots
+// and the data root, and the subtree roots against the share commitment.
+package inclusion
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/celestiaorg/nmt"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// MessageProof proves that the shares of a message are included in the data
+// root of a block
+type MessageProof struct {
+	NamespaceID []byte `json:"namespace_id"`
+	// SquareSize is the size of the original data square of the block, which
+	// is also the square size of the MsgPayForMessage that paid for the message
+	SquareSize uint64 `json:"square_size"`
+	// StartShare is the index of the first share of the message in the
+	// original data square, counting the shares row by row
+	StartShare uint64 `json:"start_share"`
+	// Rows prove the shares of the message in each row that it is laid out in
+	Rows []RowProof `json:"rows"`
+}
+
+// RowProof proves a range of shares in a row of the extended data square
+// against the row root, and the row root against the data root
+type RowProof struct {
+	Row uint64 `json:"row"`
+	// Start and End are the range [Start, End) of the columns of the shares
+	Start  uint64   `json:"start"`
+	End    uint64   `json:"end"`
+	Shares [][]byte `json:"shares"`
+	// Nodes are the nodes of the nmt range proof of the shares against the
+	// row root
+	Nodes        [][]byte     `json:"nodes"`
+	RowRoot      []byte       `json:"row_root"`
+	RowRootProof merkle.Proof `json:"row_root_proof"`
+}
+
+// NewMessageProof creates a proof that the message at the provided index of the
+// block data's messages is included in the data root of the block
+func NewMessageProof(data *coretypes.Data, msgIndex int) (*MessageProof, error) {
+	msgs := data.Messages.MessagesList
+	if msgIndex < 0 || msgIndex >= len(msgs) {
+		return nil, fmt.Errorf("message index %d out of range: block has %d messages", msgIndex, len(msgs))
+	}
+
+	shares, _ := data.ComputeShares()
+	squareSize := uint64(math.Sqrt(float64(len(shares))))
+	eds, err := da.ExtendShares(squareSize, shares.RawShares())
+	if err != nil {
+		return nil, err
+	}
+	dah := da.NewDataAvailabilityHeader(eds)
+	roots := make([][]byte, 0, 4*squareSize)
+	roots = append(append(roots, dah.RowsRoots...), dah.ColumnRoots...)
+	_, rootProofs := merkle.ProofsFromByteSlices(roots)
+
+	// messages are written after the reserved namespaces, each one starting in
+	// a new share
+	start := uint64(len(data.Txs.SplitIntoShares()) +
+		len(data.IntermediateStateRoots.SplitIntoShares()) +
+		len(data.Evidence.SplitIntoShares()) +
+		len(coretypes.Messages{MessagesList: msgs[:msgIndex]}.SplitIntoShares()))
+	end := start + uint64(len(coretypes.Messages{MessagesList: msgs[msgIndex : msgIndex+1]}.SplitIntoShares()))
+
+	hasher := newRowHasher()
+	proof := &MessageProof{
+		NamespaceID: msgs[msgIndex].NamespaceID,
+		SquareSize:  squareSize,
+		StartShare:  start,
+	}
+	for cursor := start; cursor < end; {
+		row := cursor / squareSize
+		rowEnd := (row + 1) * squareSize
+		if rowEnd > end {
+			rowEnd = end
+		}
+		startCol, endCol := cursor%squareSize, rowEnd-row*squareSize
+
+		rowShares := eds.Row(uint(row))
+		leafHashes := rowLeafHashes(hasher, squareSize, rowShares)
+		if !bytes.Equal(rangeRoot(hasher, leafHashes), dah.RowsRoots[row]) {
+			return nil, fmt.Errorf("row tree %d does not match the row root", row)
+		}
+
+		proof.Rows = append(proof.Rows, RowProof{
+			Row:          row,
+			Start:        startCol,
+			End:          endCol,
+			Shares:       rowShares[startCol:endCol],
+			Nodes:        proveRange(hasher, leafHashes, startCol, endCol),
+			RowRoot:      dah.RowsRoots[row],
+			RowRootProof: *rootProofs[row],
+		})
+		cursor = rowEnd
+	}
+	return proof, nil
+}
+
+// Verify checks that the shares of the proof are included in the block with
+// the provided data root and returns the message that they contain
+func (p MessageProof) Verify(dataRoot []byte) ([]byte, error) {
+	message, _, err := p.verify(dataRoot)
+	return message, err
+}
+
+// SubtreeRoots checks that the shares of the proof are included in the block
+// with the provided data root, and returns the subtree roots of the share
+// commitment of the message for the square size of the block, computed from
+// the proven shares
+func (p MessageProof) SubtreeRoots(dataRoot []byte) ([][]byte, error) {
+	_, roots, err := p.subtreeRoots(dataRoot)
+	return roots, err
+}
+
+// VerifyCommitment checks that the shares of the proof are included in the
+// block with the provided data root, and that the subtree roots computed from
+// them match the provided share commitment for the square size of the block
+func (p MessageProof) VerifyCommitment(dataRoot, commitment []byte) error {
+	v, roots, err := p.subtreeRoots(dataRoot)
+	if err != nil {
+		return err
+	}
+	if err := v.VerifySubtreeRoots(commitment, roots); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidProof, err.Error())
+	}
+	return nil
+}
+
+// subtreeRoots verifies the proof and computes the subtree roots from the
+// proven shares, using the commitment verifier of the message
+func (p MessageProof) subtreeRoots(dataRoot []byte) (types.CommitmentVerifier, [][]byte, error) {
+	message, shares, err := p.verify(dataRoot)
+	if err != nil {
+		return types.CommitmentVerifier{}, nil, err
+	}
+	v, err := types.NewCommitmentVerifier(p.SquareSize, p.NamespaceID, uint64(len(message)))
+	if err != nil {
+		return types.CommitmentVerifier{}, nil, sdkerrors.Wrap(types.ErrInvalidProof, err.Error())
+	}
+	// a message that is not padded like the message of a MsgPayForMessage
+	// takes up a different number of shares than its commitment is created from
+	roots, err := v.SubtreeRoots(shares)
+	if err != nil {
+		return types.CommitmentVerifier{}, nil, sdkerrors.Wrap(types.ErrInvalidProof, err.Error())
+	}
+	return v, roots, nil
+}
+
+// verify checks the proof against the data root, and returns the proven
+// shares along with the message that they contain
+func (p MessageProof) verify(dataRoot []byte) ([]byte, [][]byte, error) {
+	if err := p.validateLayout(); err != nil {
+		return nil, nil, sdkerrors.Wrap(types.ErrInvalidProof, err.Error())
+	}
+	hasher := newRowHasher()
+	var shares [][]byte
+	for _, row := range p.Rows {
+		if err := p.verifyRow(hasher, dataRoot, row); err != nil {
+			return nil, nil, sdkerrors.Wrapf(types.ErrInvalidProof, "row %d: %s", row.Row, err)
+		}
+		shares = append(shares, row.Shares...)
+	}
+	message, err := decodeMessage(shares)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(types.ErrInvalidProof, err.Error())
+	}
+	return message, shares, nil
+}
+
+// validateLayout checks that the rows of the proof contiguously cover the
+// shares of the message, starting at the start share
+func (p MessageProof) validateLayout() error {
+	if len(p.NamespaceID) != consts.NamespaceSize {
+		return fmt.Errorf("namespace id of %d bytes, expected %d", len(p.NamespaceID), consts.NamespaceSize)
+	}
+	k := p.SquareSize
+	if k < consts.MinSquareSize || k > consts.MaxSquareSize || !types.IsPowerOf2(k) {
+		return fmt.Errorf("invalid square size %d", k)
+	}
+	if len(p.Rows) == 0 {
+		return fmt.Errorf("no rows")
+	}
+	cursor := p.StartShare
+	for i, row := range p.Rows {
+		if row.Row != cursor/k || row.Start != cursor%k {
+			return fmt.Errorf("row %d does not start at share %d", i, cursor)
+		}
+		if row.End <= row.Start || row.End > k {
+			return fmt.Errorf("row %d has an invalid range [%d, %d)", i, row.Start, row.End)
+		}
+		if i != len(p.Rows)-1 && row.End != k {
+			return fmt.Errorf("row %d does not end at the end of the row", i)
+		}
+		if uint64(len(row.Shares)) != row.End-row.Start {
+			return fmt.Errorf("row %d has %d shares, expected %d", i, len(row.Shares), row.End-row.Start)
+		}
+		cursor += row.End - row.Start
+	}
+	if cursor > k*k {
+		return fmt.Errorf("shares end at %d, past the end of the square", cursor)
+	}
+	return nil
+}
+
+// verifyRow checks that the shares of the row are included in the row root,
+// and that the row root is included in the data root
+func (p MessageProof) verifyRow(hasher *nmt.Hasher, dataRoot []byte, row RowProof) error {
+	leafHashes := make([][]byte, len(row.Shares))
+	for i, share := range row.Shares {
+		if len(share) != consts.ShareSize {
+			return fmt.Errorf("share of %d bytes, expected %d", len(share), consts.ShareSize)
+		}
+		if !bytes.Equal(share[:consts.NamespaceSize], p.NamespaceID) {
+			return fmt.Errorf("share %d is not in namespace %X", i, p.NamespaceID)
+		}
+		leafHashes[i] = rowLeafHash(hasher, p.SquareSize, row.Start+uint64(i), share)
+	}
+	if !verifyRange(hasher, 2*p.SquareSize, row.Start, row.End, leafHashes, row.Nodes, row.RowRoot) {
+		return fmt.Errorf("shares are not included in the row root")
+	}
+
+	if row.RowRootProof.Total != int64(4*p.SquareSize) || row.RowRootProof.Index != int64(row.Row) {
+		return fmt.Errorf("row root proof is not for row %d", row.Row)
+	}
+	return row.RowRootProof.Verify(dataRoot, row.RowRoot)
+}
+
+// decodeMessage returns the message that celestia-core split into the provided
+// shares, which is prefixed with its uvarint encoded length
+func decodeMessage(shares [][]byte) ([]byte, error) {
+	data := make([]byte, 0, len(shares)*consts.MsgShareSize)
+	for _, share := range shares {
+		data = append(data, share[consts.NamespaceSize:]...)
+	}
+	msgLen, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid message length prefix")
+	}
+	if msgLen > uint64(len(data)-n) {
+		return nil, fmt.Errorf("message of %d bytes does not fit in %d shares", msgLen, len(shares))
+	}
+	if types.MessageShares(msgLen) != uint64(len(shares)) {
+		return nil, fmt.Errorf("message of %d bytes does not use %d shares", msgLen, len(shares))
+	}
+	return data[n : uint64(n)+msgLen], nil
+}
@@ -0,0 +1,205 @@
+package inclusion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/celestiaorg/nmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/pkg/consts"
+	"github.com/tendermint/tendermint/pkg/da"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// testData returns block data with messages that start in the middle of a row
+// and span several rows of a square of size 4. The messages are padded like the
+// messages of MsgPayForMessages.
+func testData() *coretypes.Data {
+	return &coretypes.Data{
+		Txs: coretypes.Txs{bytes.Repeat([]byte{1}, 100)},
+		Messages: coretypes.Messages{MessagesList: []coretypes.Message{
+			{NamespaceID: []byte{1, 1, 1, 1, 1, 1, 1, 1}, Data: bytes.Repeat([]byte{1}, types.ShareSize)},
+			{NamespaceID: []byte{2, 2, 2, 2, 2, 2, 2, 2}, Data: bytes.Repeat([]byte{2}, 6*types.ShareSize)},
+			{NamespaceID: []byte{3, 3, 3, 3, 3, 3, 3, 3}, Data: []byte{}},
+			{NamespaceID: []byte{4, 4, 4, 4, 4, 4, 4, 4}, Data: bytes.Repeat([]byte{4}, 2*types.ShareSize)},
+		}},
+	}
+}
+
+func TestMessageProof(t *testing.T) {
+	data := testData()
+	dataRoot := data.Hash()
+
+	for i, msg := range data.Messages.MessagesList {
+		proof, err := NewMessageProof(data, i)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), proof.SquareSize)
+
+		message, err := proof.Verify(dataRoot)
+		require.NoError(t, err)
+		assert.Equal(t, msg.Data, message)
+
+		commitment, err := types.CreateCommitment(proof.SquareSize, msg.NamespaceID, msg.Data)
+		require.NoError(t, err)
+		assert.NoError(t, proof.VerifyCommitment(dataRoot, commitment))
+
+		// the subtree roots computed from the proven shares are the subtree
+		// roots of the commitment
+		roots, err := proof.SubtreeRoots(dataRoot)
+		require.NoError(t, err)
+		v, err := types.NewCommitmentVerifier(proof.SquareSize, msg.NamespaceID, uint64(len(msg.Data)))
+		require.NoError(t, err)
+		expected, err := v.SubtreeRoots(types.MessageToShares(msg.NamespaceID, msg.Data))
+		require.NoError(t, err)
+		assert.Equal(t, expected, roots)
+	}
+
+	// the second message spans several rows
+	proof, err := NewMessageProof(data, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), proof.StartShare)
+	assert.Len(t, proof.Rows, 3)
+
+	_, err = NewMessageProof(data, 4)
+	assert.Error(t, err)
+}
+
+func TestInvalidMessageProof(t *testing.T) {
+	data := testData()
+	dataRoot := data.Hash()
+	msg := data.Messages.MessagesList[1]
+	commitment, err := types.CreateCommitment(4, msg.NamespaceID, msg.Data)
+	require.NoError(t, err)
+
+	type test struct {
+		name       string
+		mutate     func(*MessageProof)
+		dataRoot   []byte
+		commitment []byte
+	}
+	tests := []test{
+		{
+			name: "modified share",
+			mutate: func(p *MessageProof) {
+				p.Rows[1].Shares[0] = append([]byte{}, p.Rows[1].Shares[0]...)
+				p.Rows[1].Shares[0][consts.NamespaceSize]++
+			},
+		},
+		{
+			name:     "different data root",
+			mutate:   func(*MessageProof) {},
+			dataRoot: bytes.Repeat([]byte{1}, 32),
+		},
+		{
+			name:       "different commitment",
+			mutate:     func(*MessageProof) {},
+			commitment: bytes.Repeat([]byte{1}, 32),
+		},
+		{
+			name: "missing proof node",
+			mutate: func(p *MessageProof) {
+				p.Rows[0].Nodes = p.Rows[0].Nodes[1:]
+			},
+		},
+		{
+			name: "extra proof node",
+			mutate: func(p *MessageProof) {
+				nodes := p.Rows[0].Nodes
+				p.Rows[0].Nodes = append(nodes[:len(nodes):len(nodes)], nodes[0])
+			},
+		},
+		{
+			name: "truncated proof node",
+			mutate: func(p *MessageProof) {
+				p.Rows[0].Nodes = append([][]byte{}, p.Rows[0].Nodes...)
+				p.Rows[0].Nodes[0] = p.Rows[0].Nodes[0][1:]
+			},
+		},
+		{
+			name: "proof of a different row",
+			mutate: func(p *MessageProof) {
+				p.Rows[1].Nodes = p.Rows[2].Nodes
+			},
+		},
+		{
+			name: "different start share",
+			mutate: func(p *MessageProof) {
+				p.StartShare++
+			},
+		},
+		{
+			name: "missing row",
+			mutate: func(p *MessageProof) {
+				p.Rows = p.Rows[:len(p.Rows)-1]
+			},
+		},
+		{
+			name: "different namespace",
+			mutate: func(p *MessageProof) {
+				p.NamespaceID = []byte{3, 3, 3, 3, 3, 3, 3, 3}
+			},
+		},
+		{
+			name: "row root proof for a different row",
+			mutate: func(p *MessageProof) {
+				p.Rows[0].RowRootProof = p.Rows[1].RowRootProof
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		proof, err := NewMessageProof(data, 1)
+		require.NoError(t, err)
+		tt.mutate(proof)
+		root, commit := dataRoot, commitment
+		if tt.dataRoot != nil {
+			root = tt.dataRoot
+		}
+		if tt.commitment != nil {
+			commit = tt.commitment
+		}
+		assert.ErrorIs(t, proof.VerifyCommitment(root, commit), types.ErrInvalidProof, tt.name)
+	}
+}
+
+func TestRangeProof(t *testing.T) {
+	data := testData()
+	shares, _ := data.ComputeShares()
+	squareSize := uint64(4)
+	eds, err := da.ExtendShares(squareSize, shares.RawShares())
+	require.NoError(t, err)
+	dah := da.NewDataAvailabilityHeader(eds)
+	hasher := newRowHasher()
+
+	for row := uint64(0); row < squareSize; row++ {
+		rowShares := eds.Row(uint(row))
+		leafHashes := rowLeafHashes(hasher, squareSize, rowShares)
+		rowRoot := []byte(dah.RowsRoots[row])
+		assert.Equal(t, rowRoot, rangeRoot(hasher, leafHashes))
+
+		// the proofs of single shares are the inclusion proofs of the nmt
+		tree := nmt.New(consts.NewBaseHashFunc(), nmt.NamespaceIDSize(consts.NamespaceSize), nmt.IgnoreMaxNamespace(true))
+		for col, share := range rowShares {
+			nid := share[:consts.NamespaceSize]
+			if uint64(col) >= squareSize {
+				nid = consts.ParitySharesNamespaceID
+			}
+			require.NoError(t, tree.Push(append(append([]byte{}, nid...), share...)))
+		}
+		for col := uint64(0); col < 2*squareSize; col++ {
+			nmtProof, err := tree.Prove(int(col))
+			require.NoError(t, err)
+			assert.Equal(t, nmtProof.Nodes(), proveRange(hasher, leafHashes, col, col+1))
+		}
+
+		for start := uint64(0); start < 2*squareSize; start++ {
+			for end := start + 1; end <= 2*squareSize; end++ {
+				nodes := proveRange(hasher, leafHashes, start, end)
+				assert.True(t, verifyRange(hasher, 2*squareSize, start, end, leafHashes[start:end], nodes, rowRoot))
+				assert.False(t, verifyRange(hasher, 2*squareSize, start, end, leafHashes[start:end], nodes, dah.ColumnRoots[row]))
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package keeper
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ types.QueryServer = Keeper{}
+
+// Params returns the parameters of the payment module
+func (k Keeper) Params(goCtx context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryParamsResponse{Params: k.GetParams(ctx)}, nil
+}
+
+// NamespaceStats returns the totals of the messages paid for in a namespace
+func (k Keeper) NamespaceStats(goCtx context.Context, req *types.QueryNamespaceStatsRequest) (*types.QueryNamespaceStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	namespaceID, err := parseNamespaceID(req.NamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QueryNamespaceStatsResponse{Stats: k.GetNamespaceStats(ctx, namespaceID)}, nil
+}
+
+// SignerStats returns the totals of the messages paid for by a signer
+func (k Keeper) SignerStats(goCtx context.Context, req *types.QuerySignerStatsRequest) (*types.QuerySignerStatsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	signer, err := sdk.AccAddressFromBech32(req.Signer)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid signer: %s", err)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	return &types.QuerySignerStatsResponse{Stats: k.GetSignerStats(ctx, signer)}, nil
+}
+
+// Commitment calculates the share commitment of a message for a square size.
+// The message is padded in the same way as when creating a
+// MsgWirePayForMessage, so the returned commitment and message size can be
+// compared to the ones of a MsgWirePayForMessage. The square size and message
+// size are bounded by the params, and the commitment is not cached, so that
+// queries can't evict the commitments that are cached for CheckTx and block
+// proposals.
+func (k Keeper) Commitment(goCtx context.Context, req *types.QueryCommitmentRequest) (*types.QueryCommitmentResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	namespaceID, err := parseNamespaceID(req.NamespaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := k.GetParams(ctx)
+	if req.SquareSize < params.MinSquareSize || req.SquareSize > params.MaxSquareSize || !types.IsPowerOf2(req.SquareSize) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"square size %d is not a power of two between %d and %d",
+			req.SquareSize,
+			params.MinSquareSize,
+			params.MaxSquareSize,
+		)
+	}
+	// the message is padded to a multiple of the share size
+	messageSize := (uint64(len(req.Message)) + types.ShareSize - 1) / types.ShareSize * types.ShareSize
+	if messageSize > params.MaxMessageBytes {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"message size %d is larger than %d",
+			messageSize,
+			params.MaxMessageBytes,
+		)
+	}
+
+	commit, err := types.CreateCommitment(req.SquareSize, namespaceID, req.Message)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &types.QueryCommitmentResponse{
+		ShareCommitment: commit,
+		MessageSize:     messageSize,
+		Shares:          types.MessageShares(messageSize),
+	}, nil
+}
+
+// parseNamespaceID decodes a hex encoded namespace id
+func parseNamespaceID(namespaceID string) ([]byte, error) {
+	bz, err := hex.DecodeString(namespaceID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid namespace id: %s", err)
+	}
+	if len(bz) != types.NamespaceIDSize {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid namespace id length: got %d wanted %d",
+			len(bz),
+			types.NamespaceIDSize,
+		)
+	}
+	return bz, nil
+}
+
+// Rejection returns why a tx was recently left out of a block proposed by this
+// node
+func (k Keeper) Rejection(_ context.Context, req *types.QueryRejectionRequest) (*types.QueryRejectionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	if req.TxHash == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty tx hash")
+	}
+
+	rejection, has := k.rejections.Get(req.TxHash)
+	if !has {
+		return nil, status.Errorf(codes.NotFound, "no recent rejection for tx %s", req.TxHash)
+	}
+	return &types.QueryRejectionResponse{Rejection: &rejection}, nil
+}
+
+// MalleatedTx returns the malleated tx that was included in a block in place of
+// a tx containing MsgWirePayForMessages
+func (k Keeper) MalleatedTx(goCtx context.Context, req *types.QueryMalleatedTxRequest) (*types.QueryMalleatedTxResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid request")
+	}
+	parentHash, err := hex.DecodeString(req.ParentHash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tx hash: %s", err)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	malleatedTx, has := k.GetMalleatedTx(ctx, parentHash)
+	if !has {
+		return nil, status.Errorf(codes.NotFound, "no malleated tx for tx %s", req.ParentHash)
+	}
+	return &types.QueryMalleatedTxResponse{MalleatedTx: malleatedTx}, nil
+}
@@ -2,43 +2,148 @@ package keeper
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strconv"
 
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/celestiaorg/celestia-app/x/payment/types"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 )
 
 // Keeper handles all the state changes for the celestia-app module.
 type Keeper struct {
-	cdc      codec.BinaryCodec
-	storeKey sdk.StoreKey
-	memKey   sdk.StoreKey
-	bank     BankKeeper
+	cdc        codec.BinaryCodec
+	storeKey   sdk.StoreKey
+	memKey     sdk.StoreKey
+	paramSpace paramtypes.Subspace
+	bank       BankKeeper
+	rejections *RejectionCache
 }
 
-func NewKeeper(cdc codec.BinaryCodec, bank BankKeeper, storeKey, memKey sdk.StoreKey) *Keeper {
+func NewKeeper(cdc codec.BinaryCodec, bank BankKeeper, storeKey, memKey sdk.StoreKey, paramSpace paramtypes.Subspace) *Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
 	return &Keeper{
-		cdc:      cdc,
-		storeKey: storeKey,
-		memKey:   memKey,
-		bank:     bank,
+		cdc:        cdc,
+		storeKey:   storeKey,
+		memKey:     memKey,
+		paramSpace: paramSpace,
+		bank:       bank,
+		rejections: NewRejectionCache(DefaultRejectionCacheSize),
 	}
 }
 
+// Rejections returns the cache of txs recently rejected by this node's block
+// producer
+func (k Keeper) Rejections() *RejectionCache {
+	return k.rejections
+}
+
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
 }
 
-//  MsgPayForMessage moves a user's coins to the module address and burns them.
+// PayForMessage charges the signer for each share used by the message, using
+// the price per share param. The payment is moved from the signer to the module
+// account, and is then split between being burned and sent to the fee collector
+// using the burn ratio param. The message is added to the totals of its
+// namespace and signer, the malleated tx is indexed by the hash of the original
+// tx, and events are emitted so that the message can be found by its namespace
+// and signer. Gas for the message is consumed by the
+// MessageGasDecorator before the msg is executed.
 func (k Keeper) PayForMessage(goCtx context.Context, msg *types.MsgPayForMessage) (*types.MsgPayForMessageResponse, error) {
+	ctx := sdk.UnwrapSDKContext(goCtx)
+
+	signer, err := sdk.AccAddressFromBech32(msg.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	params := k.GetParams(ctx)
+	if msg.MessageSize > params.MaxMessageBytes {
+		return nil, sdkerrors.Wrapf(
+			types.ErrMessageTooLarge,
+			"%d bytes is larger than %d",
+			msg.MessageSize,
+			params.MaxMessageBytes,
+		)
+	}
+
+	payment := params.PaymentForShares(types.MessageShares(msg.MessageSize))
+	if !payment.IsZero() {
+		if err := k.pay(ctx, signer, payment, params); err != nil {
+			return nil, err
+		}
+	}
+
+	k.recordPayment(ctx, signer, msg, payment)
+	k.recordMalleatedTx(ctx)
+
+	if err := emitPayForMessageEvents(ctx, msg, payment); err != nil {
+		return nil, err
+	}
+
 	return &types.MsgPayForMessageResponse{}, nil
 }
 
+// emitPayForMessageEvents emits the EventPayForMessage typed event, along with
+// a flattened version of it whose attributes can be used in tx search queries
+func emitPayForMessageEvents(ctx sdk.Context, msg *types.MsgPayForMessage, payment sdk.Coin) error {
+	event := types.EventPayForMessage{
+		Signer:          msg.Signer,
+		NamespaceId:     hex.EncodeToString(msg.MessageNamespaceId),
+		MessageSize:     msg.MessageSize,
+		ShareCommitment: hex.EncodeToString(msg.MessageShareCommitment),
+		FeePaid:         payment,
+	}
+	if err := ctx.EventManager().EmitTypedEvent(&event); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePayForMessage,
+		sdk.NewAttribute(types.AttributeKeySigner, event.Signer),
+		sdk.NewAttribute(types.AttributeKeyNamespace, event.NamespaceId),
+		sdk.NewAttribute(types.AttributeKeyMessageSize, strconv.FormatUint(event.MessageSize, 10)),
+		sdk.NewAttribute(types.AttributeKeyShareCommitment, event.ShareCommitment),
+		sdk.NewAttribute(types.AttributeKeyFeePaid, event.FeePaid.String()),
+	))
+	return nil
+}
+
+// pay moves the payment from the signer to the module account, and then splits
+// it between being burned and sent to the fee collector
+func (k Keeper) pay(ctx sdk.Context, signer sdk.AccAddress, payment sdk.Coin, params types.Params) error {
+	err := k.bank.SendCoinsFromAccountToModule(ctx, signer, types.ModuleName, sdk.NewCoins(payment))
+	if err != nil {
+		return sdkerrors.Wrapf(err, "failed to pay %s for message", payment)
+	}
+
+	burned, distributed := params.SplitPayment(payment)
+	if burned.IsPositive() {
+		err = k.bank.BurnCoins(ctx, types.ModuleName, sdk.NewCoins(burned))
+		if err != nil {
+			return err
+		}
+	}
+	if distributed.IsPositive() {
+		return k.bank.SendCoinsFromModuleToModule(ctx, types.ModuleName, authtypes.FeeCollectorName, sdk.NewCoins(distributed))
+	}
+	return nil
+}
+
 // BankKeeper restricts the funtionality of the bank keeper used in the payment keeper
 type BankKeeper interface {
 	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToModule(ctx sdk.Context, senderModule, recipientModule string, amt sdk.Coins) error
 	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
 }
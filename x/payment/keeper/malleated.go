@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// GetMalleatedTx returns the malleated tx that was included in a block in
+// place of the tx with the provided hash
+func (k Keeper) GetMalleatedTx(ctx sdk.Context, parentHash []byte) (types.MalleatedTx, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.MalleatedTxPrefix)
+	bz := store.Get(parentHash)
+	if bz == nil {
+		return types.MalleatedTx{}, false
+	}
+	var malleatedTx types.MalleatedTx
+	k.cdc.MustUnmarshal(bz, &malleatedTx)
+	return malleatedTx, true
+}
+
+func (k Keeper) setMalleatedTx(ctx sdk.Context, parentHash []byte, malleatedTx types.MalleatedTx) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.MalleatedTxPrefix)
+	store.Set(parentHash, k.cdc.MustMarshal(&malleatedTx))
+}
+
+// recordMalleatedTx indexes the tx that is being executed by the hash of its
+// original tx, if it was malleated by the block producer. The decoded tx does
+// not include the original tx's hash, so it is read from the raw tx instead.
+func (k Keeper) recordMalleatedTx(ctx sdk.Context) {
+	parentHash, childTx, isMalleated := coretypes.UnwrapMalleatedTx(ctx.TxBytes())
+	if !isMalleated {
+		return
+	}
+	k.setMalleatedTx(ctx, parentHash, types.MalleatedTx{
+		ParentHash: strings.ToUpper(hex.EncodeToString(parentHash)),
+		Hash:       strings.ToUpper(hex.EncodeToString(tmhash.Sum(childTx))),
+		Height:     ctx.BlockHeight(),
+	})
+}
@@ -0,0 +1,22 @@
+package keeper
+
+import (
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetParams returns the total set of payment module parameters. Any parameter
+// that has not been set yet falls back to its default value, which allows for
+// the params to be read before the genesis state has been committed.
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+// SetParams sets the total set of payment module parameters.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
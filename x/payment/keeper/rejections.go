@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+)
+
+// DefaultRejectionCacheSize is the number of recent rejections that are kept
+// in memory by default
+const DefaultRejectionCacheSize = 10000
+
+// RejectionCache keeps the most recent rejections of txs by the block producer
+// in memory, so that submitters can find out why their tx was left out of a
+// block. Rejections are only observed by the node that proposed the block, so
+// they are not part of the state machine. Once the cache is full, the oldest
+// rejection is evicted.
+type RejectionCache struct {
+	mtx sync.RWMutex
+
+	size   int
+	hashes []string
+	next   int
+	byHash map[string]types.Rejection
+}
+
+// NewRejectionCache creates a RejectionCache that holds up to size rejections
+func NewRejectionCache(size int) *RejectionCache {
+	return &RejectionCache{
+		size:   size,
+		hashes: make([]string, 0, size),
+		byHash: make(map[string]types.Rejection, size),
+	}
+}
+
+// Add records a rejection, replacing any previous rejection of the same tx
+func (rc *RejectionCache) Add(rejection types.Rejection) {
+	if rc.size <= 0 {
+		return
+	}
+	rejection.TxHash = strings.ToUpper(rejection.TxHash)
+
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+
+	// a tx that is rejected again keeps its place in the cache
+	if _, has := rc.byHash[rejection.TxHash]; has {
+		rc.byHash[rejection.TxHash] = rejection
+		return
+	}
+
+	if len(rc.hashes) < rc.size {
+		rc.hashes = append(rc.hashes, rejection.TxHash)
+	} else {
+		delete(rc.byHash, rc.hashes[rc.next])
+		rc.hashes[rc.next] = rejection.TxHash
+	}
+	rc.next = (rc.next + 1) % rc.size
+	rc.byHash[rejection.TxHash] = rejection
+}
+
+// Get returns the rejection of the tx with the provided hex encoded hash
+func (rc *RejectionCache) Get(txHash string) (types.Rejection, bool) {
+	rc.mtx.RLock()
+	defer rc.mtx.RUnlock()
+	rejection, has := rc.byHash[strings.ToUpper(txHash)]
+	return rejection, has
+}
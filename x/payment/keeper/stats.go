@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"github.com/celestiaorg/celestia-app/x/payment/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GetNamespaceStats returns the totals of the messages paid for in the
+// provided namespace. Empty stats are returned if no message has been paid for
+// in the namespace yet.
+func (k Keeper) GetNamespaceStats(ctx sdk.Context, namespaceID []byte) types.NamespaceStats {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.NamespaceStatsPrefix)
+	bz := store.Get(namespaceID)
+	if bz == nil {
+		return types.NamespaceStats{NamespaceId: namespaceID}
+	}
+	var stats types.NamespaceStats
+	k.cdc.MustUnmarshal(bz, &stats)
+	return stats
+}
+
+func (k Keeper) setNamespaceStats(ctx sdk.Context, stats types.NamespaceStats) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.NamespaceStatsPrefix)
+	store.Set(stats.NamespaceId, k.cdc.MustMarshal(&stats))
+}
+
+// GetSignerStats returns the totals of the messages paid for by the provided
+// signer. Empty stats are returned if the signer has not paid for a message
+// yet.
+func (k Keeper) GetSignerStats(ctx sdk.Context, signer sdk.AccAddress) types.SignerStats {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.SignerStatsPrefix)
+	bz := store.Get(signer)
+	if bz == nil {
+		return types.SignerStats{Signer: signer.String()}
+	}
+	var stats types.SignerStats
+	k.cdc.MustUnmarshal(bz, &stats)
+	return stats
+}
+
+func (k Keeper) setSignerStats(ctx sdk.Context, signer sdk.AccAddress, stats types.SignerStats) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.SignerStatsPrefix)
+	store.Set(signer, k.cdc.MustMarshal(&stats))
+}
+
+// recordPayment adds a paid for message to the totals of its namespace and
+// signer
+func (k Keeper) recordPayment(ctx sdk.Context, signer sdk.AccAddress, msg *types.MsgPayForMessage, payment sdk.Coin) {
+	nsStats := k.GetNamespaceStats(ctx, msg.MessageNamespaceId)
+	nsStats.TotalBytes += msg.MessageSize
+	nsStats.TotalMessages++
+	nsStats.LastHeight = ctx.BlockHeight()
+	k.setNamespaceStats(ctx, nsStats)
+
+	signerStats := k.GetSignerStats(ctx, signer)
+	signerStats.TotalBytes += msg.MessageSize
+	signerStats.TotalMessages++
+	if payment.IsPositive() {
+		signerStats.TotalPaid = signerStats.TotalPaid.Add(payment)
+	}
+	signerStats.LastHeight = ctx.BlockHeight()
+	k.setSignerStats(ctx, signer, signerStats)
+}
@@ -0,0 +1,259 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	coretypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultInclusionPollInterval is how often a BlobClient checks if a submitted
+// tx was included in a block
+const DefaultInclusionPollInterval = time.Second
+
+// BlobClient submits messages to a celestia-app node, and waits for the
+// malleated txs that pay for them to be included in a block
+type BlobClient struct {
+	signer *KeyringSigner
+	conn   *grpc.ClientConn
+
+	pollInterval time.Duration
+
+	// accountMtx protects queriedAccount, which records if the account number
+	// and sequence of the signer were queried
+	accountMtx     sync.Mutex
+	queriedAccount bool
+}
+
+// NewBlobClient returns a BlobClient that signs txs using the provided signer
+// and submits them using the provided gRPC connection
+func NewBlobClient(signer *KeyringSigner, conn *grpc.ClientConn) *BlobClient {
+	return &BlobClient{
+		signer:       signer,
+		conn:         conn,
+		pollInterval: DefaultInclusionPollInterval,
+	}
+}
+
+// SetPollInterval sets how often the client checks if a submitted tx was
+// included in a block
+func (c *BlobClient) SetPollInterval(interval time.Duration) {
+	c.pollInterval = interval
+}
+
+// PayForMessageResult describes the malleated tx that paid for a message once
+// it is included in a block
+type PayForMessageResult struct {
+	// Height is the height of the block that included the malleated tx
+	Height int64
+	// TxHash is the hex encoded hash of the malleated tx
+	TxHash string
+	// ParentHash is the hex encoded hash of the tx that was broadcast
+	ParentHash string
+	// Signer is the bech32 encoded address of the account that paid for the
+	// message
+	Signer string
+	// SquareSize is the size of the square of the block that included the
+	// message
+	SquareSize uint64
+	// ShareCommitment is the share commitment of the message for SquareSize
+	ShareCommitment []byte
+}
+
+// SubmitPayForMessage creates a MsgWirePayForMessage for the message, signs
+// and broadcasts it, and waits for the malleated tx to be included in a block.
+// Share commitments are created for every square size that the message fits
+// in. The account of the signer is queried before the first submission, and
+// its sequence is kept by the signer afterwards. The node is polled until the
+// malleated tx is included, so the context should have a deadline. If the
+// context is done before the malleated tx is included, the returned error wraps
+// the context's error, and the tx might still be included later.
+func (c *BlobClient) SubmitPayForMessage(
+	ctx context.Context,
+	namespace, message []byte,
+	options ...TxBuilderOption,
+) (*PayForMessageResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.queryAccount(ctx); err != nil {
+		return nil, err
+	}
+
+	msg, err := NewWirePayForMessage(namespace, message, AllSquareSizes(len(message))...)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.signer.BroadcastMsgs(ctx, c.conn, tx.BroadcastMode_BROADCAST_MODE_SYNC, []sdktypes.Msg{msg}, options...)
+	if err != nil {
+		return nil, err
+	}
+	txResp := resp.TxResponse
+	if txResp == nil {
+		return nil, fmt.Errorf("no tx response")
+	}
+	if txResp.Code != 0 {
+		return nil, sdkerrors.ABCIError(txResp.Codespace, txResp.Code, txResp.RawLog)
+	}
+
+	return c.waitForInclusion(ctx, txResp.TxHash)
+}
+
+// queryAccount queries the account number and sequence of the signer, unless
+// they were already queried
+func (c *BlobClient) queryAccount(ctx context.Context) error {
+	c.accountMtx.Lock()
+	defer c.accountMtx.Unlock()
+
+	if c.queriedAccount {
+		return nil
+	}
+	if err := c.signer.QueryAccountNumber(ctx, c.conn); err != nil {
+		return err
+	}
+	c.queriedAccount = true
+	return nil
+}
+
+// waitForInclusion waits for the tx with the provided hash to be included in a
+// block, and reads the result of its MsgPayForMessage
+func (c *BlobClient) waitForInclusion(ctx context.Context, parentHash string) (*PayForMessageResult, error) {
+	resp, err := waitForTx(ctx, c.conn, c.pollInterval, parentHash)
+	if err != nil {
+		return nil, err
+	}
+	return c.inclusionResult(ctx, parentHash, resp)
+}
+
+// waitForTx polls the node until the tx with the provided hash is included in
+// a block, or the context is done. Txs are indexed using the hash of the tx
+// that was broadcast, even when a malleated tx is included instead. Only the
+// errors of a tx that is not found are retried, so a tx that is never included
+// is waited for until the context is done, and callers need a deadline.
+func waitForTx(ctx context.Context, conn *grpc.ClientConn, pollInterval time.Duration, hash string) (*tx.GetTxResponse, error) {
+	txClient := tx.NewServiceClient(conn)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := txClient.GetTx(ctx, &tx.GetTxRequest{Hash: hash})
+		switch {
+		case err == nil:
+			return resp, nil
+		case !isTxNotFound(err):
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %s was not included in a block: %w", hash, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTxNotFound checks if GetTx failed because the tx is not included in a block
+// yet. The node returns the error of its tx index, "tx (<hash>) not found", as
+// an invalid request, with the InvalidArgument code instead of NotFound.
+func isTxNotFound(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return true
+	case codes.InvalidArgument:
+		return strings.Contains(st.Message(), ") not found")
+	default:
+		return false
+	}
+}
+
+// inclusionResult reads the result of the MsgPayForMessage from the malleated
+// tx that was included in a block
+func (c *BlobClient) inclusionResult(ctx context.Context, parentHash string, resp *tx.GetTxResponse) (*PayForMessageResult, error) {
+	txResp := resp.TxResponse
+	if txResp.Code != 0 {
+		return nil, sdkerrors.ABCIError(txResp.Codespace, txResp.Code, txResp.RawLog)
+	}
+
+	pfmMsg, err := findPayForMessage(resp.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	malleatedResp, err := NewQueryClient(c.conn).MalleatedTx(ctx, &QueryMalleatedTxRequest{ParentHash: parentHash})
+	if err != nil {
+		return nil, err
+	}
+
+	squareSize, err := BlockSquareSize(ctx, c.conn, txResp.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayForMessageResult{
+		Height:          txResp.Height,
+		TxHash:          malleatedResp.MalleatedTx.Hash,
+		ParentHash:      parentHash,
+		Signer:          pfmMsg.Signer,
+		SquareSize:      squareSize,
+		ShareCommitment: pfmMsg.MessageShareCommitment,
+	}, nil
+}
+
+// BlockSquareSize returns the size of the original data square of the block at
+// the provided height. celestia-core lays out the block data in the smallest
+// square that fits it, but passes neither the square size nor the evidence of
+// the block to the app, so the square size is derived from the whole block
+// data, which can be larger than the default max gRPC message size.
+func BlockSquareSize(ctx context.Context, conn *grpc.ClientConn, height int64) (uint64, error) {
+	resp, err := tmservice.NewServiceClient(conn).GetBlockByHeight(
+		ctx,
+		&tmservice.GetBlockByHeightRequest{Height: height},
+		grpc.MaxCallRecvMsgSize(math.MaxInt32),
+	)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Block == nil {
+		return 0, fmt.Errorf("no block at height %d", height)
+	}
+	data, err := coretypes.DataFromProto(&resp.Block.Data)
+	if err != nil {
+		return 0, err
+	}
+	shares, _ := data.ComputeShares()
+	return SquareSizeForShares(uint64(len(shares))), nil
+}
+
+// findPayForMessage returns the MsgPayForMessage of a malleated tx
+func findPayForMessage(malleatedTx *tx.Tx) (*MsgPayForMessage, error) {
+	if malleatedTx == nil || malleatedTx.Body == nil {
+		return nil, fmt.Errorf("malleated tx has no body")
+	}
+	typeURL := sdktypes.MsgTypeURL(&MsgPayForMessage{})
+	for _, anyMsg := range malleatedTx.Body.Messages {
+		if anyMsg.TypeUrl != typeURL {
+			continue
+		}
+		var pfmMsg MsgPayForMessage
+		if err := pfmMsg.Unmarshal(anyMsg.Value); err != nil {
+			return nil, err
+		}
+		return &pfmMsg, nil
+	}
+	return nil, fmt.Errorf("malleated tx does not contain a %s", typeURL)
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsTxNotFound(t *testing.T) {
+	type test struct {
+		name     string
+		err      error
+		notFound bool
+	}
+	tests := []test{
+		{
+			name:     "tx index error returned by the node",
+			err:      status.Error(codes.InvalidArgument, "tx (AA00) not found: invalid request"),
+			notFound: true,
+		},
+		{
+			name:     "not found code",
+			err:      status.Error(codes.NotFound, "tx not found"),
+			notFound: true,
+		},
+		{
+			name: "invalid hash",
+			err:  status.Error(codes.InvalidArgument, "encoding/hex: invalid byte: U+007A 'z': invalid request"),
+		},
+		{
+			name: "unavailable node",
+			err:  status.Error(codes.Unavailable, "connection refused"),
+		},
+		{
+			name: "error without a status",
+			err:  errors.New("tx not found"),
+		},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.notFound, isTxNotFound(tt.err), tt.name)
+	}
+}
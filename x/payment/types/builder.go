@@ -2,11 +2,14 @@ package types
 
 import (
 	"context"
+	"regexp"
+	"strconv"
 	"sync"
 
 	sdkclient "github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
@@ -15,6 +18,14 @@ import (
 	"google.golang.org/grpc"
 )
 
+// DefaultMaxSequenceRetries is the number of times that BroadcastMsgs
+// resubmits a tx that is rejected because of an account sequence mismatch
+const DefaultMaxSequenceRetries = 3
+
+// sequenceMismatchRegexp matches the error that the ante handler returns when
+// a tx is signed with the wrong sequence
+var sequenceMismatchRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+), got \d+`)
+
 // KeyringSigner uses a keyring to sign and build celestia-app transactions
 type KeyringSigner struct {
 	keyring.Keyring
@@ -25,6 +36,10 @@ type KeyringSigner struct {
 	encCfg         cosmoscmd.EncodingConfig
 
 	sync.RWMutex
+
+	// broadcastMtx makes sure that txs broadcast with BroadcastMsgs are
+	// signed and submitted one at a time, so that each uses the next sequence
+	broadcastMtx sync.Mutex
 }
 
 // NewKeyringSigner returns a new KeyringSigner using the provided keyring
@@ -64,17 +79,17 @@ func (k *KeyringSigner) NewTxBuilder() sdkclient.TxBuilder {
 	return k.encCfg.TxConfig.NewTxBuilder()
 }
 
-// BuildSignedTx creates and signs a sdk.Tx that contains the provided message. The interal
+// BuildSignedTx creates and signs a sdk.Tx that contains the provided messages. The interal
 // account number must be set by calling k.QueryAccountNumber or by manually setting it via
 // k.SetAccountNumber for the built transactions to be valid.
-func (k *KeyringSigner) BuildSignedTx(builder sdkclient.TxBuilder, msg sdktypes.Msg) (authsigning.Tx, error) {
+func (k *KeyringSigner) BuildSignedTx(builder sdkclient.TxBuilder, msgs ...sdktypes.Msg) (authsigning.Tx, error) {
 	k.RLock()
 	accountNumber := k.accountNumber
 	sequence := k.sequence
 	k.RUnlock()
 
-	// set the msg
-	err := builder.SetMsgs(msg)
+	// set the msgs
+	err := builder.SetMsgs(msgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +173,121 @@ func (k *KeyringSigner) SetSequence(n uint64) {
 	k.sequence = n
 }
 
+// GetSequence returns the sequence that the next tx is signed with
+func (k *KeyringSigner) GetSequence() uint64 {
+	k.RLock()
+	defer k.RUnlock()
+
+	return k.sequence
+}
+
+// BroadcastMsgs signs a tx containing the provided msgs with the next sequence
+// of the signer and broadcasts it. The share commitments of any
+// MsgWirePayForMessages are signed as well. Once the tx is accepted by the node,
+// the sequence is incremented, so that several txs can be submitted in a row
+// without waiting for them to be included in a block. If the tx is rejected
+// because of an account sequence mismatch, the account is queried again and
+// the tx is signed and resubmitted with the sequence that the node expects, up
+// to DefaultMaxSequenceRetries times. Other failures are returned in the
+// response, as with BroadcastTx.
+func (k *KeyringSigner) BroadcastMsgs(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	mode tx.BroadcastMode,
+	msgs []sdktypes.Msg,
+	options ...TxBuilderOption,
+) (*tx.BroadcastTxResponse, error) {
+	k.broadcastMtx.Lock()
+	defer k.broadcastMtx.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		rawTx, err := k.signMsgs(msgs, options...)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := BroadcastTx(ctx, conn, mode, rawTx)
+		if err != nil {
+			return nil, err
+		}
+
+		txResp := resp.TxResponse
+		switch {
+		case txResp == nil:
+			return resp, nil
+		case isSequenceMismatch(txResp) && attempt < DefaultMaxSequenceRetries:
+			if err := k.resyncSequence(ctx, conn, txResp.RawLog); err != nil {
+				return resp, err
+			}
+		case txResp.Code == 0 || txResp.Height > 0:
+			// the sequence is used once the tx is accepted by the node, and
+			// also when it is included in a block but fails
+			k.Lock()
+			k.sequence++
+			k.Unlock()
+			return resp, nil
+		default:
+			return resp, nil
+		}
+	}
+}
+
+// signMsgs builds, signs, and encodes a tx containing the provided msgs using
+// the current sequence
+func (k *KeyringSigner) signMsgs(msgs []sdktypes.Msg, options ...TxBuilderOption) ([]byte, error) {
+	var wireMsgs []*MsgWirePayForMessage
+	for _, msg := range msgs {
+		if wireMsg, ok := msg.(*MsgWirePayForMessage); ok {
+			wireMsgs = append(wireMsgs, wireMsg)
+		}
+	}
+	// the malleated txs use the same sequence as the tx, so the share
+	// commitments are signed again for every attempt
+	if len(wireMsgs) != 0 {
+		if err := SignWirePayForMessages(k, wireMsgs, options...); err != nil {
+			return nil, err
+		}
+	}
+
+	builder := k.NewTxBuilder()
+	for _, option := range options {
+		builder = option(builder)
+	}
+	signedTx, err := k.BuildSignedTx(builder, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	return k.EncodeTx(signedTx)
+}
+
+// resyncSequence updates the account number and sequence of the signer after
+// a tx was rejected because of an account sequence mismatch. The queried
+// sequence does not include the txs of the signer that are still in the
+// mempool, so the sequence that the node expects is used when the rejection
+// includes it.
+func (k *KeyringSigner) resyncSequence(ctx context.Context, conn *grpc.ClientConn, rawLog string) error {
+	if err := k.QueryAccountNumber(ctx, conn); err != nil {
+		return err
+	}
+	match := sequenceMismatchRegexp.FindStringSubmatch(rawLog)
+	if match == nil {
+		return nil
+	}
+	expected, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return nil
+	}
+	k.SetSequence(expected)
+	return nil
+}
+
+// isSequenceMismatch checks if a tx was rejected because it was signed with
+// the wrong sequence
+func isSequenceMismatch(txResp *sdktypes.TxResponse) bool {
+	return txResp != nil &&
+		txResp.Codespace == sdkerrors.ErrWrongSequence.Codespace() &&
+		txResp.Code == sdkerrors.ErrWrongSequence.ABCICode()
+}
+
 // SetKeyringAccName manually sets the underlying keyring account name
 func (k *KeyringSigner) SetKeyringAccName(name string) {
 	k.keyringAccName = name
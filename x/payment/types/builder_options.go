@@ -20,3 +20,10 @@ func SetFeeAmount(fees sdk.Coins) TxBuilderOption {
 		return builder
 	}
 }
+
+func SetFeeGranter(granter sdk.AccAddress) TxBuilderOption {
+	return func(builder sdkclient.TxBuilder) sdkclient.TxBuilder {
+		builder.SetFeeGranter(granter)
+		return builder
+	}
+}
@@ -0,0 +1,275 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/celestiaorg/nmt"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// minParallelCommitmentShares is the number of shares from which the subtree
+// roots of a message are computed concurrently, as the goroutines cost more
+// than they save for small messages
+const minParallelCommitmentShares = 64
+
+// CommitmentVerifier verifies the share commitments of messages of a given
+// size and namespace for a square of size k. A share commitment is the merkle
+// root of the roots of the subtrees of a merkle mountain range over the shares
+// that celestia-core lays the message out in, so it can be verified using the
+// shares of the message, or using the subtree roots alone.
+type CommitmentVerifier struct {
+	namespace []byte
+	heights   []uint64
+}
+
+// NewCommitmentVerifier creates a CommitmentVerifier for a message of the
+// provided size in a square of size k, which is padded to a multiple of the
+// share size in the same way as CreateCommitment pads it
+func NewCommitmentVerifier(k uint64, namespace []byte, msgSize uint64) (CommitmentVerifier, error) {
+	return newCommitmentVerifier(k, namespace, MessageShares(PaddedMessageSize(msgSize)))
+}
+
+// newCommitmentVerifier creates a CommitmentVerifier for a message that takes
+// up the provided number of shares in a square of size k
+func newCommitmentVerifier(k uint64, namespace []byte, shareCount uint64) (CommitmentVerifier, error) {
+	// see CreateCommitment for why a share is reserved
+	if shareCount > k*k-1 {
+		return CommitmentVerifier{}, fmt.Errorf("message size exceeds square size")
+	}
+	return CommitmentVerifier{
+		namespace: namespace,
+		heights:   powerOf2MountainRange(shareCount, k),
+	}, nil
+}
+
+// SubtreeHeights returns the number of shares in each subtree of the merkle
+// mountain range, from left to right
+func (v CommitmentVerifier) SubtreeHeights() []uint64 {
+	return v.heights
+}
+
+// shareCount returns the number of shares of the message
+func (v CommitmentVerifier) shareCount() uint64 {
+	count := uint64(0)
+	for _, height := range v.heights {
+		count += height
+	}
+	return count
+}
+
+// SubtreeRange returns the range [start, end) of the shares of the message that
+// are the leaves of the subtree at the provided index
+func (v CommitmentVerifier) SubtreeRange(index int) (start, end uint64) {
+	for _, height := range v.heights[:index] {
+		start += height
+	}
+	return start, start + v.heights[index]
+}
+
+// SubtreeRoot computes the root of a subtree from the shares that are its
+// leaves. Each leaf is a share prefixed with the namespace, in the same way as
+// the leaves of the row trees of the data square.
+func (v CommitmentVerifier) SubtreeRoot(shares [][]byte) ([]byte, error) {
+	hasher := newSubtreeHasher()
+	leaf := make([]byte, len(v.namespace)+ShareSize)
+	copy(leaf, v.namespace)
+	leafHashes := make([][]byte, len(shares))
+	for i, share := range shares {
+		if len(share) != ShareSize {
+			return nil, fmt.Errorf("share of %d bytes, expected %d", len(share), ShareSize)
+		}
+		copy(leaf[len(v.namespace):], share)
+		leafHashes[i] = hasher.HashLeaf(leaf)
+	}
+	return subtreeRoot(hasher, leafHashes), nil
+}
+
+// SubtreeRoots computes the root of each subtree from the shares of the
+// message
+func (v CommitmentVerifier) SubtreeRoots(shares [][]byte) ([][]byte, error) {
+	if total := v.shareCount(); uint64(len(shares)) != total {
+		return nil, fmt.Errorf("%d shares, expected %d", len(shares), total)
+	}
+	workers := 1
+	if len(shares) >= minParallelCommitmentShares {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return v.subtreeRoots(shares, workers)
+}
+
+// subtreeRoots computes the roots of the subtrees using up to the provided
+// number of goroutines. The subtrees are independent of each other, so each
+// goroutine computes the roots of a different set of subtrees.
+func (v CommitmentVerifier) subtreeRoots(shares [][]byte, workers int) ([][]byte, error) {
+	if workers > len(v.heights) {
+		workers = len(v.heights)
+	}
+	roots := make([][]byte, len(v.heights))
+	errs := make([]error, len(v.heights))
+	compute := func(i int) {
+		start, end := v.SubtreeRange(i)
+		roots[i], errs[i] = v.SubtreeRoot(shares[start:end])
+	}
+
+	if workers <= 1 {
+		for i := range v.heights {
+			compute(i)
+		}
+	} else {
+		next := int64(-1)
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for w := 0; w < workers; w++ {
+			go func() {
+				defer wg.Done()
+				for i := int(atomic.AddInt64(&next, 1)); i < len(v.heights); i = int(atomic.AddInt64(&next, 1)) {
+					compute(i)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return roots, nil
+}
+
+// Commitment returns the share commitment for the provided subtree roots
+func (v CommitmentVerifier) Commitment(subtreeRoots [][]byte) []byte {
+	return merkle.HashFromByteSlices(subtreeRoots)
+}
+
+// VerifyShares checks that the share commitment is created from the provided
+// shares of the message
+func (v CommitmentVerifier) VerifyShares(commitment []byte, shares [][]byte) error {
+	roots, err := v.SubtreeRoots(shares)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidShareCommitment, err.Error())
+	}
+	return v.VerifySubtreeRoots(commitment, roots)
+}
+
+// VerifySubtreeRoots checks that the share commitment is created from the
+// provided subtree roots, without needing the shares of the message. Each root
+// must be the root of a namespaced merkle tree that only contains shares of
+// the verifier's namespace.
+func (v CommitmentVerifier) VerifySubtreeRoots(commitment []byte, subtreeRoots [][]byte) error {
+	if len(subtreeRoots) != len(v.heights) {
+		return sdkerrors.Wrapf(ErrInvalidShareCommitment, "%d subtree roots, expected %d", len(subtreeRoots), len(v.heights))
+	}
+	for i, root := range subtreeRoots {
+		if !v.inNamespace(root) {
+			return sdkerrors.Wrapf(ErrInvalidShareCommitment, "subtree root %d is not in namespace %X", i, v.namespace)
+		}
+	}
+	if !bytes.Equal(v.Commitment(subtreeRoots), commitment) {
+		return sdkerrors.Wrap(ErrInvalidShareCommitment, "subtree roots do not match the commitment")
+	}
+	return nil
+}
+
+// SubtreeRootProof proves that a single subtree root is part of a share
+// commitment
+type SubtreeRootProof struct {
+	// Index is the index of the subtree in the merkle mountain range
+	Index int          `json:"index"`
+	Root  []byte       `json:"root"`
+	Proof merkle.Proof `json:"proof"`
+}
+
+// ProveSubtreeRoot creates a proof that the subtree root at the provided index
+// is part of the share commitment created from the subtree roots
+func (v CommitmentVerifier) ProveSubtreeRoot(subtreeRoots [][]byte, index int) (SubtreeRootProof, error) {
+	if len(subtreeRoots) != len(v.heights) {
+		return SubtreeRootProof{}, fmt.Errorf("%d subtree roots, expected %d", len(subtreeRoots), len(v.heights))
+	}
+	if index < 0 || index >= len(subtreeRoots) {
+		return SubtreeRootProof{}, fmt.Errorf("subtree index %d out of range", index)
+	}
+	_, proofs := merkle.ProofsFromByteSlices(subtreeRoots)
+	return SubtreeRootProof{
+		Index: index,
+		Root:  subtreeRoots[index],
+		Proof: *proofs[index],
+	}, nil
+}
+
+// VerifySubtreeRootProof checks that the proven subtree root is part of the
+// share commitment
+func (v CommitmentVerifier) VerifySubtreeRootProof(commitment []byte, proof SubtreeRootProof) error {
+	if proof.Index < 0 || proof.Index >= len(v.heights) {
+		return sdkerrors.Wrapf(ErrInvalidShareCommitment, "subtree index %d out of range", proof.Index)
+	}
+	if proof.Proof.Total != int64(len(v.heights)) || proof.Proof.Index != int64(proof.Index) {
+		return sdkerrors.Wrapf(ErrInvalidShareCommitment, "proof is not for subtree %d", proof.Index)
+	}
+	if !v.inNamespace(proof.Root) {
+		return sdkerrors.Wrapf(ErrInvalidShareCommitment, "subtree root is not in namespace %X", v.namespace)
+	}
+	if err := proof.Proof.Verify(commitment, proof.Root); err != nil {
+		return sdkerrors.Wrap(ErrInvalidShareCommitment, err.Error())
+	}
+	return nil
+}
+
+// VerifySubtreeShares checks that the provided shares are the leaves of the
+// proven subtree, and that the subtree is part of the share commitment
+func (v CommitmentVerifier) VerifySubtreeShares(commitment []byte, proof SubtreeRootProof, shares [][]byte) error {
+	if err := v.VerifySubtreeRootProof(commitment, proof); err != nil {
+		return err
+	}
+	if uint64(len(shares)) != v.heights[proof.Index] {
+		return sdkerrors.Wrapf(ErrInvalidShareCommitment, "%d shares, expected %d", len(shares), v.heights[proof.Index])
+	}
+	root, err := v.SubtreeRoot(shares)
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidShareCommitment, err.Error())
+	}
+	if !bytes.Equal(root, proof.Root) {
+		return sdkerrors.Wrap(ErrInvalidShareCommitment, "shares do not match the subtree root")
+	}
+	return nil
+}
+
+// inNamespace checks that the min and max namespace of a subtree root are the
+// verifier's namespace
+func (v CommitmentVerifier) inNamespace(root []byte) bool {
+	if len(root) < 2*NamespaceIDSize {
+		return false
+	}
+	return bytes.Equal(nmt.MinNamespace(root, NamespaceIDSize), v.namespace) &&
+		bytes.Equal(nmt.MaxNamespace(root, NamespaceIDSize), v.namespace)
+}
+
+// VerifyCommitment checks that the share commitment for a square of size k is
+// created from the provided shares of the message, which are the shares
+// returned by MessageToShares, or the shares of the message in a block
+func VerifyCommitment(k uint64, namespace, commitment []byte, shares [][]byte) error {
+	v, err := newCommitmentVerifier(k, namespace, uint64(len(shares)))
+	if err != nil {
+		return sdkerrors.Wrap(ErrInvalidShareCommitment, err.Error())
+	}
+	return v.VerifyShares(commitment, shares)
+}
+
+// MessageToShares pads the message in the same way as NewWirePayForMessage,
+// and splits it into the shares that celestia-core lays it out in, which its
+// share commitment is created from. The message is prefixed with its uvarint
+// encoded length, and each share starts with the namespace. As the leaves of
+// the subtrees are the same as the leaves of the row trees that contain the
+// message, the subtree roots can be computed from the shares of the message in
+// a block.
+func MessageToShares(namespace, message []byte) [][]byte {
+	msgs := coretypes.Messages{MessagesList: []coretypes.Message{{NamespaceID: namespace, Data: padMessage(message)}}}
+	return msgs.SplitIntoShares().RawShares()
+}
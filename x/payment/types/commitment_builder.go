@@ -0,0 +1,223 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/celestiaorg/nmt"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+// CommitmentBuilder creates the share commitments of a message for several
+// square sizes at once, while the message is written to it. Each share is
+// hashed into a leaf of the namespaced merkle trees as soon as it is complete,
+// so the builder never holds more than a share of the message, and at most k
+// leaf hashes for each square size k. The shares are the same as the ones
+// returned by MessageToShares, so the size of the message must be known
+// upfront, as celestia-core prefixes the message with its length. The message
+// is padded in the same way as CreateCommitment pads it.
+type CommitmentBuilder struct {
+	namespace []byte
+	hasher    *nmt.Hasher
+
+	// leaf holds the namespace followed by the share that is being written,
+	// which starts with the namespace as well
+	leaf []byte
+	// written is the number of bytes written to the data of the share
+	written int
+	// shares is the number of complete shares
+	shares uint64
+
+	msgSize uint64
+	// remaining is the number of bytes of the message that are not written
+	remaining uint64
+	// padded is true once the padding of the message is written
+	padded bool
+
+	ranges []*mountainRange
+}
+
+// mountainRange keeps track of the merkle mountain range of the message for a
+// square size
+type mountainRange struct {
+	k uint64
+	// roots are the roots of the subtrees of k shares that are complete
+	roots [][]byte
+	// leafHashes are the hashes of the shares that don't complete a subtree of
+	// k shares yet
+	leafHashes [][]byte
+	overflow   bool
+}
+
+// NewCommitmentBuilder creates a CommitmentBuilder for a message of the
+// provided size and the provided square sizes
+func NewCommitmentBuilder(namespace []byte, msgSize uint64, squareSizes ...uint64) *CommitmentBuilder {
+	leaf := make([]byte, len(namespace)+ShareSize)
+	copy(leaf, namespace)
+	copy(leaf[len(namespace):], namespace)
+	ranges := make([]*mountainRange, len(squareSizes))
+	for i, k := range squareSizes {
+		ranges[i] = &mountainRange{k: k}
+	}
+	b := &CommitmentBuilder{
+		namespace: namespace,
+		hasher:    newSubtreeHasher(),
+		leaf:      leaf,
+		msgSize:   msgSize,
+		remaining: msgSize,
+		ranges:    ranges,
+	}
+
+	// the padded message is prefixed with its uvarint encoded length
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	b.write(lenBuf[:binary.PutUvarint(lenBuf, PaddedMessageSize(msgSize))])
+	return b
+}
+
+// Write fulfills the io.Writer interface by adding the bytes to the message.
+// An error is returned if more bytes are written than the size of the message.
+func (b *CommitmentBuilder) Write(p []byte) (int, error) {
+	if uint64(len(p)) > b.remaining {
+		n := int(b.remaining)
+		b.write(p[:n])
+		b.remaining = 0
+		return n, fmt.Errorf("message is larger than %d bytes", b.msgSize)
+	}
+	b.write(p)
+	b.remaining -= uint64(len(p))
+	return len(p), nil
+}
+
+// write adds the bytes to the data of the shares, pushing each share once it
+// is complete
+func (b *CommitmentBuilder) write(p []byte) {
+	data := b.leaf[2*len(b.namespace):]
+	for len(p) > 0 {
+		copied := copy(data[b.written:], p)
+		b.written += copied
+		p = p[copied:]
+		if b.written == len(data) {
+			b.pushShare()
+		}
+	}
+}
+
+// pushShare adds the hash of the share that is being written to the mountain
+// range of each square size
+func (b *CommitmentBuilder) pushShare() {
+	leafHash := b.hasher.HashLeaf(b.leaf)
+	b.shares++
+	b.written = 0
+	for _, mr := range b.ranges {
+		// see CreateCommitment for why a share is reserved
+		if b.shares > mr.k*mr.k-1 {
+			mr.overflow = true
+		}
+		if mr.overflow {
+			continue
+		}
+		mr.leafHashes = append(mr.leafHashes, leafHash)
+		if uint64(len(mr.leafHashes)) == mr.k {
+			mr.roots = append(mr.roots, subtreeRoot(b.hasher, mr.leafHashes))
+			mr.leafHashes = mr.leafHashes[:0]
+		}
+	}
+}
+
+// Commitment returns the share commitment of the message for the square size
+// k, which must be one of the square sizes of the builder. The whole message
+// must be written first, and no more bytes can be written afterwards.
+func (b *CommitmentBuilder) Commitment(k uint64) ([]byte, error) {
+	if b.remaining != 0 {
+		return nil, fmt.Errorf("%d of the %d bytes of the message are not written", b.remaining, b.msgSize)
+	}
+	b.finish()
+	for _, mr := range b.ranges {
+		if mr.k != k {
+			continue
+		}
+		if mr.overflow {
+			return nil, fmt.Errorf("message size exceeds square size %d", k)
+		}
+		roots := make([][]byte, 0, len(mr.roots)+len(mr.leafHashes))
+		roots = append(roots, mr.roots...)
+		cursor := uint64(0)
+		for _, height := range powerOf2MountainRange(uint64(len(mr.leafHashes)), k) {
+			roots = append(roots, subtreeRoot(b.hasher, mr.leafHashes[cursor:cursor+height]))
+			cursor += height
+		}
+		return merkle.HashFromByteSlices(roots), nil
+	}
+	return nil, fmt.Errorf("no commitment is built for square size %d", k)
+}
+
+// Commitments returns the share commitment of the message for each square
+// size, in the same order as the square sizes were provided. No more bytes can
+// be written afterwards.
+func (b *CommitmentBuilder) Commitments() ([][]byte, error) {
+	commits := make([][]byte, len(b.ranges))
+	for i, mr := range b.ranges {
+		commit, err := b.Commitment(mr.k)
+		if err != nil {
+			return nil, err
+		}
+		commits[i] = commit
+	}
+	return commits, nil
+}
+
+// finish writes the padding of the message, and pads and pushes the last share
+// of the message if it is incomplete
+func (b *CommitmentBuilder) finish() {
+	if !b.padded {
+		b.write(make([]byte, PaddedMessageSize(b.msgSize)-b.msgSize))
+		b.padded = true
+	}
+	if b.written == 0 {
+		return
+	}
+	data := b.leaf[2*len(b.namespace):]
+	for i := b.written; i < len(data); i++ {
+		data[i] = 0
+	}
+	b.pushShare()
+}
+
+// CreateCommitmentFromReader creates the share commitment for a square of
+// size k of the message of the provided size read from the reader, without
+// keeping the message in memory
+func CreateCommitmentFromReader(k uint64, namespace []byte, msgSize uint64, r io.Reader) ([]byte, error) {
+	b := NewCommitmentBuilder(namespace, msgSize, k)
+	if _, err := io.Copy(b, r); err != nil {
+		return nil, err
+	}
+	commits, err := b.Commitments()
+	if err != nil {
+		return nil, err
+	}
+	return commits[0], nil
+}
+
+// newSubtreeHasher returns a hasher for the namespaced merkle trees of the
+// subtrees of a share commitment
+func newSubtreeHasher() *nmt.Hasher {
+	return nmt.NewNmtHasher(sha256.New(), NamespaceIDSize, true)
+}
+
+// subtreeRoot computes the root of a namespaced merkle tree from the hashes of
+// its leaves, splitting the leaves in the same way as the nmt
+func subtreeRoot(hasher *nmt.Hasher, leafHashes [][]byte) []byte {
+	switch len(leafHashes) {
+	case 0:
+		return hasher.EmptyRoot()
+	case 1:
+		return leafHashes[0]
+	}
+	split := 1
+	for split*2 < len(leafHashes) {
+		split *= 2
+	}
+	return hasher.HashNode(subtreeRoot(hasher, leafHashes[:split]), subtreeRoot(hasher, leafHashes[split:]))
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitmentBuilder(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	sizes := []uint64{8, 16, 32}
+	for _, msgSize := range []int{0, 1, ShareSize - 1, ShareSize, ShareSize + 1, 11*ShareSize + 10, 16 * ShareSize} {
+		message := bytes.Repeat([]byte{0xAB}, msgSize)
+
+		builder := NewCommitmentBuilder(namespace, uint64(msgSize), sizes...)
+		// write the message in uneven pieces
+		for i := 0; i < len(message); i += 100 {
+			end := i + 100
+			if end > len(message) {
+				end = len(message)
+			}
+			n, err := builder.Write(message[i:end])
+			require.NoError(t, err)
+			require.Equal(t, end-i, n)
+		}
+		commits, err := builder.Commitments()
+		require.NoError(t, err)
+
+		for i, k := range sizes {
+			expected, err := CreateCommitment(k, namespace, message)
+			require.NoError(t, err)
+			assert.Equal(t, expected, commits[i], "message of %d bytes, square size %d", msgSize, k)
+
+			commit, err := CreateCommitmentFromReader(k, namespace, uint64(msgSize), iotest.HalfReader(bytes.NewReader(message)))
+			require.NoError(t, err)
+			assert.Equal(t, expected, commit)
+		}
+	}
+}
+
+func TestCommitmentBuilderErrors(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	builder := NewCommitmentBuilder(namespace, 5*ShareSize, 2, 4)
+	_, err := builder.Write(bytes.Repeat([]byte{1}, 5*ShareSize))
+	require.NoError(t, err)
+
+	// the message does not fit in a square of size 2, but it does in 4
+	_, err = builder.Commitment(2)
+	assert.Error(t, err)
+	_, err = builder.Commitment(4)
+	assert.NoError(t, err)
+	_, err = builder.Commitments()
+	assert.Error(t, err)
+
+	// no commitment is built for a square size of 8
+	_, err = builder.Commitment(8)
+	assert.Error(t, err)
+
+	// the size of the message is part of the commitment, so exactly that many
+	// bytes must be written
+	builder = NewCommitmentBuilder(namespace, ShareSize, 4)
+	n, err := builder.Write(bytes.Repeat([]byte{1}, ShareSize+1))
+	assert.Error(t, err)
+	assert.Equal(t, ShareSize, n)
+
+	builder = NewCommitmentBuilder(namespace, ShareSize, 4)
+	_, err = builder.Write(bytes.Repeat([]byte{1}, ShareSize-1))
+	require.NoError(t, err)
+	_, err = builder.Commitment(4)
+	assert.Error(t, err)
+}
+
+// BenchmarkCommitmentAllocations compares the memory allocated to create the
+// share commitment of a 2 MB message that is kept in memory to the memory
+// allocated when the message is streamed
+func BenchmarkCommitmentAllocations(b *testing.B) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 2*1024*1024-10)
+	k := uint64(128)
+
+	b.Run("in memory", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := CreateCommitment(k, namespace, message); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("streamed", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := CreateCommitmentFromReader(k, namespace, uint64(len(message)), bytes.NewReader(message)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+)
+
+// DefaultCommitmentCacheSize is the number of share commitments that are kept
+// in memory by default
+const DefaultCommitmentCacheSize = 10000
+
+// commitmentCache is shared by every caller in the process, so that a node
+// reuses the share commitments that it verified in CheckTx when it malleates
+// the tx in PreprocessTxs, and a client reuses the share commitments that it
+// created when it validates the msg before broadcasting it
+var commitmentCache = NewCommitmentCache(DefaultCommitmentCacheSize)
+
+// CommitmentCache keeps the most recently created share commitments in memory,
+// keyed by the hash of the message, its namespace, and the square size. Once
+// the cache is full, the oldest commitment is evicted.
+type CommitmentCache struct {
+	mtx sync.RWMutex
+
+	size        int
+	keys        []string
+	next        int
+	commitments map[string][]byte
+}
+
+// NewCommitmentCache creates a CommitmentCache that holds up to size share
+// commitments
+func NewCommitmentCache(size int) *CommitmentCache {
+	return &CommitmentCache{
+		size:        size,
+		keys:        make([]string, 0, size),
+		commitments: make(map[string][]byte, size),
+	}
+}
+
+// Commitments returns the share commitment of the message for each of the
+// provided square sizes, in the same order. Commitments that are not cached
+// are created and added to the cache.
+func (cc *CommitmentCache) Commitments(namespace, message []byte, squareSizes ...uint64) ([][]byte, error) {
+	msgHash := sha256.Sum256(message)
+	commits := make([][]byte, len(squareSizes))
+	for i, k := range squareSizes {
+		key := commitmentKey(msgHash[:], namespace, k)
+		if commit, has := cc.get(key); has {
+			commits[i] = commit
+			continue
+		}
+		commit, err := CreateCommitment(k, namespace, message)
+		if err != nil {
+			return nil, err
+		}
+		cc.add(key, commit)
+		commits[i] = append([]byte{}, commit...)
+	}
+	return commits, nil
+}
+
+// addCommitments caches share commitments of the message that were created
+// outside of the cache
+func (cc *CommitmentCache) addCommitments(namespace, message []byte, squareSizes []uint64, commits [][]byte) {
+	msgHash := sha256.Sum256(message)
+	for i, k := range squareSizes {
+		cc.add(commitmentKey(msgHash[:], namespace, k), append([]byte{}, commits[i]...))
+	}
+}
+
+// Len returns the number of cached share commitments
+func (cc *CommitmentCache) Len() int {
+	cc.mtx.RLock()
+	defer cc.mtx.RUnlock()
+	return len(cc.commitments)
+}
+
+// get returns a copy of the cached share commitment, so that callers can't
+// modify the cache
+func (cc *CommitmentCache) get(key string) ([]byte, bool) {
+	cc.mtx.RLock()
+	defer cc.mtx.RUnlock()
+	commit, has := cc.commitments[key]
+	if !has {
+		return nil, false
+	}
+	return append([]byte{}, commit...), true
+}
+
+func (cc *CommitmentCache) add(key string, commit []byte) {
+	if cc.size <= 0 {
+		return
+	}
+
+	cc.mtx.Lock()
+	defer cc.mtx.Unlock()
+
+	// the commitment might have been added concurrently
+	if _, has := cc.commitments[key]; has {
+		return
+	}
+
+	if len(cc.keys) < cc.size {
+		cc.keys = append(cc.keys, key)
+	} else {
+		delete(cc.commitments, cc.keys[cc.next])
+		cc.keys[cc.next] = key
+	}
+	cc.next = (cc.next + 1) % cc.size
+	cc.commitments[key] = commit
+}
+
+// commitmentKey creates the cache key of a share commitment
+func commitmentKey(msgHash, namespace []byte, k uint64) string {
+	var kBytes [8]byte
+	binary.BigEndian.PutUint64(kBytes[:], k)
+	key := make([]byte, 0, len(msgHash)+len(namespace)+len(kBytes))
+	key = append(append(append(key, msgHash...), namespace...), kBytes[:]...)
+	return string(key)
+}
+
+// CachedCommitments returns the share commitment of the message for each of
+// the provided square sizes using the cache shared by the process
+func CachedCommitments(namespace, message []byte, squareSizes ...uint64) ([][]byte, error) {
+	return commitmentCache.Commitments(namespace, message, squareSizes...)
+}
@@ -0,0 +1,245 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitmentVerifier(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 11*ShareSize)
+	shares := MessageToShares(namespace, message)
+	require.Len(t, shares, 12)
+
+	commitment, err := CreateCommitment(4, namespace, message)
+	require.NoError(t, err)
+
+	v, err := NewCommitmentVerifier(4, namespace, uint64(len(message)))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4, 4, 4}, v.SubtreeHeights())
+	start, end := v.SubtreeRange(1)
+	assert.Equal(t, uint64(4), start)
+	assert.Equal(t, uint64(8), end)
+
+	roots, err := v.SubtreeRoots(shares)
+	require.NoError(t, err)
+	assert.Equal(t, commitment, v.Commitment(roots))
+	assert.NoError(t, v.VerifyShares(commitment, shares))
+	assert.NoError(t, v.VerifySubtreeRoots(commitment, roots))
+	assert.NoError(t, VerifyCommitment(4, namespace, commitment, shares))
+
+	for i := range roots {
+		proof, err := v.ProveSubtreeRoot(roots, i)
+		require.NoError(t, err)
+		assert.NoError(t, v.VerifySubtreeRootProof(commitment, proof))
+		start, end := v.SubtreeRange(i)
+		assert.NoError(t, v.VerifySubtreeShares(commitment, proof, shares[start:end]))
+	}
+
+	_, err = NewCommitmentVerifier(2, namespace, uint64(len(message)))
+	assert.Error(t, err)
+}
+
+func TestMessageToShares(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 3*ShareSize-10)
+	shares := MessageToShares(namespace, message)
+	require.Len(t, shares, int(MessageShares(3*ShareSize)))
+
+	// the shares contain the length delimited padded message, after the
+	// namespace
+	var data []byte
+	for _, share := range shares {
+		require.Len(t, share, ShareSize)
+		assert.Equal(t, namespace, share[:NamespaceIDSize])
+		data = append(data, share[NamespaceIDSize:]...)
+	}
+	msgLen, n := binary.Uvarint(data)
+	assert.Equal(t, uint64(3*ShareSize), msgLen)
+	assert.Equal(t, padMessage(message), data[n:n+int(msgLen)])
+}
+
+func TestInvalidCommitment(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 5*ShareSize)
+	commitment, err := CreateCommitment(4, namespace, message)
+	require.NoError(t, err)
+	v, err := NewCommitmentVerifier(4, namespace, uint64(len(message)))
+	require.NoError(t, err)
+	roots, err := v.SubtreeRoots(MessageToShares(namespace, message))
+	require.NoError(t, err)
+
+	otherNamespace := []byte{8, 7, 6, 5, 4, 3, 2, 1}
+	otherVerifier, err := NewCommitmentVerifier(4, otherNamespace, uint64(len(message)))
+	require.NoError(t, err)
+	otherRoots, err := otherVerifier.SubtreeRoots(MessageToShares(otherNamespace, message))
+	require.NoError(t, err)
+
+	modified := MessageToShares(namespace, message)
+	modified[4] = bytes.Repeat([]byte{2}, ShareSize)
+
+	type test struct {
+		name   string
+		verify func() error
+	}
+	tests := []test{
+		{
+			name: "modified share",
+			verify: func() error {
+				return VerifyCommitment(4, namespace, commitment, modified)
+			},
+		},
+		{
+			name: "missing share",
+			verify: func() error {
+				return VerifyCommitment(4, namespace, commitment, MessageToShares(namespace, message)[1:])
+			},
+		},
+		{
+			name: "message does not fit the square",
+			verify: func() error {
+				return VerifyCommitment(2, namespace, commitment, MessageToShares(namespace, message))
+			},
+		},
+		{
+			name: "missing subtree root",
+			verify: func() error {
+				return v.VerifySubtreeRoots(commitment, roots[1:])
+			},
+		},
+		{
+			name: "subtree roots of a different namespace",
+			verify: func() error {
+				return v.VerifySubtreeRoots(otherVerifier.Commitment(otherRoots), otherRoots)
+			},
+		},
+		{
+			name: "subtree root proof for a different index",
+			verify: func() error {
+				proof, err := v.ProveSubtreeRoot(roots, 0)
+				require.NoError(t, err)
+				proof.Index = 1
+				return v.VerifySubtreeRootProof(commitment, proof)
+			},
+		},
+		{
+			name: "subtree shares do not match the root",
+			verify: func() error {
+				proof, err := v.ProveSubtreeRoot(roots, 1)
+				require.NoError(t, err)
+				return v.VerifySubtreeShares(commitment, proof, modified[4:6])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.ErrorIs(t, tt.verify(), ErrInvalidShareCommitment, tt.name)
+	}
+}
+
+func TestSubtreeRootsConcurrently(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 70*ShareSize)
+	v, err := NewCommitmentVerifier(16, namespace, uint64(len(message)))
+	require.NoError(t, err)
+
+	sequential, err := v.subtreeRoots(MessageToShares(namespace, message), 1)
+	require.NoError(t, err)
+	concurrent, err := v.subtreeRoots(MessageToShares(namespace, message), 4)
+	require.NoError(t, err)
+	assert.Equal(t, sequential, concurrent)
+}
+
+func TestCommitmentCache(t *testing.T) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	first := bytes.Repeat([]byte{1}, 5*ShareSize)
+	second := bytes.Repeat([]byte{2}, 5*ShareSize)
+
+	cache := NewCommitmentCache(3)
+	commits, err := cache.Commitments(namespace, first, 4, 8)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	for i, k := range []uint64{4, 8} {
+		expected, err := CreateCommitment(k, namespace, first)
+		require.NoError(t, err)
+		assert.Equal(t, expected, commits[i])
+	}
+	assert.Equal(t, 2, cache.Len())
+
+	// modifying a returned commitment does not modify the cache
+	commits[0][0]++
+	cached, err := cache.Commitments(namespace, first, 4)
+	require.NoError(t, err)
+	assert.NotEqual(t, commits[0], cached[0])
+	assert.Equal(t, 2, cache.Len())
+
+	// the oldest commitments are evicted once the cache is full
+	_, err = cache.Commitments(namespace, second, 4, 8)
+	require.NoError(t, err)
+	assert.Equal(t, 3, cache.Len())
+	_, has := cache.get(commitmentKey(sha256Sum(first), namespace, 4))
+	assert.False(t, has)
+	_, has = cache.get(commitmentKey(sha256Sum(first), namespace, 8))
+	assert.True(t, has)
+
+	// the namespace is part of the key
+	otherNamespace := []byte{8, 7, 6, 5, 4, 3, 2, 1}
+	commits, err = cache.Commitments(otherNamespace, second, 4)
+	require.NoError(t, err)
+	expected, err := CreateCommitment(4, otherNamespace, second)
+	require.NoError(t, err)
+	assert.Equal(t, expected, commits[0])
+
+	_, err = cache.Commitments(namespace, first, 2)
+	assert.Error(t, err)
+
+	disabled := NewCommitmentCache(0)
+	_, err = disabled.Commitments(namespace, first, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 0, disabled.Len())
+}
+
+func sha256Sum(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// BenchmarkCommitment compares creating the share commitment of a 2 MB message
+// using a single goroutine, using a goroutine per CPU, and using the cache
+func BenchmarkCommitment(b *testing.B) {
+	namespace := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	message := bytes.Repeat([]byte{1}, 2*1024*1024)
+	k := uint64(128)
+	v, err := NewCommitmentVerifier(k, namespace, uint64(len(message)))
+	require.NoError(b, err)
+	shares := MessageToShares(namespace, message)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := v.subtreeRoots(shares, 1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := v.subtreeRoots(shares, runtime.GOMAXPROCS(0)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		cache := NewCommitmentCache(DefaultCommitmentCacheSize)
+		for i := 0; i < b.N; i++ {
+			if _, err := cache.Commitments(namespace, message, k); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
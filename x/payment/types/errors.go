@@ -8,5 +8,18 @@ import (
 
 // x/payment module sentinel errors
 var (
-	ErrSample = sdkerrors.Register(ModuleName, 1100, "sample error")
+	ErrSample                 = sdkerrors.Register(ModuleName, 1100, "sample error")
+	ErrInvalidSquareSize      = sdkerrors.Register(ModuleName, 1101, "invalid square size")
+	ErrSquareOverflow         = sdkerrors.Register(ModuleName, 1102, "block data does not fit in the square")
+	ErrUnorderedMessages      = sdkerrors.Register(ModuleName, 1103, "messages are not ordered by namespace")
+	ErrReservedNamespace      = sdkerrors.Register(ModuleName, 1104, "message uses a reserved namespace")
+	ErrUnmalleatedWirePFM     = sdkerrors.Register(ModuleName, 1105, "MsgWirePayForMessage was not malleated")
+	ErrMissingMessage         = sdkerrors.Register(ModuleName, 1106, "no matching message for MsgPayForMessage")
+	ErrUnpaidMessage          = sdkerrors.Register(ModuleName, 1107, "message is not paid for by any MsgPayForMessage")
+	ErrInvalidMalleatedTx     = sdkerrors.Register(ModuleName, 1108, "invalid malleated tx")
+	ErrMessageTooLarge        = sdkerrors.Register(ModuleName, 1109, "message is larger than the max message bytes")
+	ErrMissingCommitment      = sdkerrors.Register(ModuleName, 1110, "missing share commitment for a required square size")
+	ErrInvalidProof           = sdkerrors.Register(ModuleName, 1111, "invalid message inclusion proof")
+	ErrInvalidShareCommitment = sdkerrors.Register(ModuleName, 1112, "invalid share commitment")
+	ErrUnwrappedPFM           = sdkerrors.Register(ModuleName, 1113, "MsgPayForMessage is not in a malleated tx")
 )
@@ -0,0 +1,14 @@
+package types
+
+// The attributes of the EventTypePayForMessage event, which flattens the
+// EventPayForMessage typed event so that txs can be searched by them, e.g.
+// with the payment.namespace='0102030405060708' query
+const (
+	EventTypePayForMessage = ModuleName
+
+	AttributeKeySigner          = "signer"
+	AttributeKeyNamespace       = "namespace"
+	AttributeKeyMessageSize     = "message_size"
+	AttributeKeyShareCommitment = "share_commitment"
+	AttributeKeyFeePaid         = "fee_paid"
+)
@@ -0,0 +1,520 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: payment/events.proto
+
+package types
+
+import (
+	fmt "fmt"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// EventPayForMessage is emitted when a MsgPayForMessage is executed.
+type EventPayForMessage struct {
+	Signer string `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	// namespace_id is the hex encoded namespace id of the message.
+	NamespaceId string `protobuf:"bytes,2,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	MessageSize uint64 `protobuf:"varint,3,opt,name=message_size,json=messageSize,proto3" json:"message_size,omitempty"`
+	// share_commitment is the hex encoded share commitment of the message.
+	ShareCommitment string `protobuf:"bytes,4,opt,name=share_commitment,json=shareCommitment,proto3" json:"share_commitment,omitempty"`
+	// fee_paid is the amount charged for the shares used by the message.
+	FeePaid types.Coin `protobuf:"bytes,5,opt,name=fee_paid,json=feePaid,proto3" json:"fee_paid"`
+}
+
+func (m *EventPayForMessage) Reset()         { *m = EventPayForMessage{} }
+func (m *EventPayForMessage) String() string { return proto.CompactTextString(m) }
+func (*EventPayForMessage) ProtoMessage()    {}
+func (*EventPayForMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_14cf9a256993c8df, []int{0}
+}
+func (m *EventPayForMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventPayForMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventPayForMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventPayForMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventPayForMessage.Merge(m, src)
+}
+func (m *EventPayForMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventPayForMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventPayForMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventPayForMessage proto.InternalMessageInfo
+
+func (m *EventPayForMessage) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+func (m *EventPayForMessage) GetNamespaceId() string {
+	if m != nil {
+		return m.NamespaceId
+	}
+	return ""
+}
+
+func (m *EventPayForMessage) GetMessageSize() uint64 {
+	if m != nil {
+		return m.MessageSize
+	}
+	return 0
+}
+
+func (m *EventPayForMessage) GetShareCommitment() string {
+	if m != nil {
+		return m.ShareCommitment
+	}
+	return ""
+}
+
+func (m *EventPayForMessage) GetFeePaid() types.Coin {
+	if m != nil {
+		return m.FeePaid
+	}
+	return types.Coin{}
+}
+
+func init() {
+	proto.RegisterType((*EventPayForMessage)(nil), "payment.EventPayForMessage")
+}
+
+func init() { proto.RegisterFile("payment/events.proto", fileDescriptor_14cf9a256993c8df) }
+
+var fileDescriptor_14cf9a256993c8df = []byte{
+	// 310 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0x90, 0xcd, 0x4e, 0xc2, 0x40,
+	0x14, 0x85, 0x3b, 0x8a, 0xa0, 0xc5, 0x44, 0xd3, 0x10, 0x53, 0x59, 0x8c, 0xe8, 0x0a, 0x17, 0x76,
+	0x82, 0xec, 0x5c, 0x42, 0x34, 0x71, 0x41, 0x42, 0x70, 0xe7, 0xa6, 0x99, 0xb6, 0x97, 0x32, 0x89,
+	0xf3, 0x93, 0xde, 0x91, 0x08, 0x4f, 0xe1, 0x63, 0xb1, 0x64, 0xa9, 0x1b, 0x63, 0xe0, 0x45, 0x4c,
+	0x7f, 0xec, 0xee, 0xcc, 0x77, 0x26, 0x27, 0xf7, 0x1c, 0xb7, 0x63, 0xf8, 0x4a, 0x82, 0xb2, 0x0c,
+	0x96, 0xa0, 0x2c, 0x06, 0x26, 0xd3, 0x56, 0x7b, 0xad, 0x8a, 0x76, 0x3b, 0xa9, 0x4e, 0x75, 0xc1,
+	0x58, 0xae, 0x4a, 0xbb, 0x4b, 0x63, 0x8d, 0x52, 0x23, 0x8b, 0x38, 0x02, 0x5b, 0x0e, 0x22, 0xb0,
+	0x7c, 0xc0, 0x62, 0x2d, 0x54, 0xe9, 0xdf, 0x7c, 0x13, 0xd7, 0x7b, 0xcc, 0xf3, 0xa6, 0x7c, 0xf5,
+	0xa4, 0xb3, 0x09, 0x20, 0xf2, 0x14, 0xbc, 0x0b, 0xb7, 0x89, 0x22, 0x55, 0x90, 0xf9, 0xa4, 0x47,
+	0xfa, 0x27, 0xb3, 0xea, 0xe5, 0x5d, 0xbb, 0xa7, 0x8a, 0x4b, 0x40, 0xc3, 0x63, 0x08, 0x45, 0xe2,
+	0x1f, 0x14, 0x6e, 0xbb, 0x66, 0xcf, 0x49, 0xfe, 0x45, 0x96, 0x29, 0x21, 0x8a, 0x35, 0xf8, 0x87,
+	0x3d, 0xd2, 0x6f, 0xcc, 0xda, 0x15, 0x7b, 0x11, 0x6b, 0xf0, 0x6e, 0xdd, 0x73, 0x5c, 0xf0, 0x0c,
+	0xc2, 0x58, 0x4b, 0x29, 0x6c, 0x7e, 0xbe, 0xdf, 0x28, 0x92, 0xce, 0x0a, 0x3e, 0xae, 0xb1, 0xf7,
+	0xe0, 0x1e, 0xcf, 0x01, 0x42, 0xc3, 0x45, 0xe2, 0x1f, 0xf5, 0x48, 0xbf, 0x7d, 0x7f, 0x19, 0x94,
+	0x95, 0x82, 0xbc, 0x52, 0x50, 0x55, 0x0a, 0xc6, 0x5a, 0xa8, 0x51, 0x63, 0xf3, 0x73, 0xe5, 0xcc,
+	0x5a, 0x73, 0x80, 0x29, 0x17, 0xc9, 0x68, 0xb2, 0xd9, 0x51, 0xb2, 0xdd, 0x51, 0xf2, 0xbb, 0xa3,
+	0xe4, 0x73, 0x4f, 0x9d, 0xed, 0x9e, 0x3a, 0x5f, 0x7b, 0xea, 0xbc, 0x0e, 0x53, 0x61, 0x17, 0xef,
+	0x51, 0x10, 0x6b, 0xc9, 0x62, 0x78, 0x03, 0xb4, 0x82, 0xeb, 0x2c, 0xad, 0xf5, 0x1d, 0x37, 0x86,
+	0x7d, 0xb0, 0xff, 0xc1, 0xed, 0xca, 0x00, 0x46, 0xcd, 0x62, 0xb1, 0xe1, 0xdf, 0x00, 0x18, 0x4f,
+	0x4b, 0x35, 0x88, 0x01, 0x00, 0x00,
+}
+
+func (m *EventPayForMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventPayForMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventPayForMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.FeePaid.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintEvents(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.ShareCommitment) > 0 {
+		i -= len(m.ShareCommitment)
+		copy(dAtA[i:], m.ShareCommitment)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.ShareCommitment)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.MessageSize != 0 {
+		i = encodeVarintEvents(dAtA, i, uint64(m.MessageSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.NamespaceId) > 0 {
+		i -= len(m.NamespaceId)
+		copy(dAtA[i:], m.NamespaceId)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.NamespaceId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Signer) > 0 {
+		i -= len(m.Signer)
+		copy(dAtA[i:], m.Signer)
+		i = encodeVarintEvents(dAtA, i, uint64(len(m.Signer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintEvents(dAtA []byte, offset int, v uint64) int {
+	offset -= sovEvents(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *EventPayForMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signer)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = len(m.NamespaceId)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	if m.MessageSize != 0 {
+		n += 1 + sovEvents(uint64(m.MessageSize))
+	}
+	l = len(m.ShareCommitment)
+	if l > 0 {
+		n += 1 + l + sovEvents(uint64(l))
+	}
+	l = m.FeePaid.Size()
+	n += 1 + l + sovEvents(uint64(l))
+	return n
+}
+
+func sovEvents(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozEvents(x uint64) (n int) {
+	return sovEvents(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *EventPayForMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventPayForMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventPayForMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageSize", wireType)
+			}
+			m.MessageSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareCommitment", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareCommitment = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeePaid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthEvents
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.FeePaid.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipEvents(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthEvents
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipEvents(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowEvents
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowEvents
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthEvents
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupEvents
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthEvents
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthEvents        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowEvents          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupEvents = fmt.Errorf("proto: unexpected end of group")
+)
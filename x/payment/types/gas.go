@@ -0,0 +1,115 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	coretypes "github.com/tendermint/tendermint/types"
+)
+
+// DefaultGasAdjustment is the factor that gas estimates are multiplied by to
+// leave room for state changes between the estimate and the inclusion of a tx
+const DefaultGasAdjustment = 1.1
+
+// EstimatePayForMessageGas estimates the gas limit of a tx containing the
+// provided MsgWirePayForMessages. A MsgWirePayForMessage is never executed, so
+// the malleated tx that pays for the messages in the largest committed square
+// size is simulated instead, wrapped in the same way as it is included in a
+// block. The gas limit must also cover the wire tx in CheckTx, which is larger
+// than the malleated tx and includes a share commitment for each square size,
+// so the gas that is consumed for the extra bytes and share commitments is
+// added to the simulated gas, before multiplying it by the gas adjustment. The
+// sequence of the signer must be the sequence of the account for the
+// simulation to succeed. The msgs are not modified.
+func (k *KeyringSigner) EstimatePayForMessageGas(
+	ctx context.Context,
+	conn gogogrpc.ClientConn,
+	msgs []*MsgWirePayForMessage,
+	gasAdjustment float64,
+	options ...TxBuilderOption,
+) (uint64, error) {
+	if len(msgs) == 0 {
+		return 0, errors.New("no MsgWirePayForMessages to estimate")
+	}
+
+	// the wire tx is encoded with signed share commitments, so that it has the
+	// size of the tx that is broadcast
+	wireMsgs := make([]*MsgWirePayForMessage, len(msgs))
+	sdkMsgs := make([]sdktypes.Msg, len(msgs))
+	for i, msg := range msgs {
+		wireMsg := *msg
+		wireMsg.MessageShareCommitment = append([]ShareCommitAndSignature{}, msg.MessageShareCommitment...)
+		wireMsgs[i], sdkMsgs[i] = &wireMsg, &wireMsg
+	}
+	wireTx, err := k.signMsgs(sdkMsgs, options...)
+	if err != nil {
+		return 0, err
+	}
+
+	// the commitment to the largest square size has the largest encoding
+	var squareSize uint64
+	for _, commit := range wireMsgs[0].MessageShareCommitment {
+		if commit.K > squareSize {
+			squareSize = commit.K
+		}
+	}
+	pfmMsgs, err := malleatedMsgs(wireMsgs, squareSize)
+	if err != nil {
+		return 0, err
+	}
+	rawMalleatedTx, err := k.signMsgs(pfmMsgs, options...)
+	if err != nil {
+		return 0, err
+	}
+	// a MsgPayForMessage is only executed in a malleated tx, which is wrapped
+	// with the hash of the wire tx in the same way as in PreprocessTxs
+	malleatedTx, err := coretypes.WrapMalleatedTx(tmhash.Sum(wireTx), rawMalleatedTx)
+	if err != nil {
+		return 0, err
+	}
+
+	simResp, err := tx.NewServiceClient(conn).Simulate(ctx, &tx.SimulateRequest{TxBytes: malleatedTx})
+	if err != nil {
+		return 0, err
+	}
+	authParams, err := authtypes.NewQueryClient(conn).Params(ctx, &authtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, err
+	}
+	paymentParams, err := NewQueryClient(conn).Params(ctx, &QueryParamsRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	gas := simResp.GasInfo.GasUsed
+	if len(wireTx) > len(malleatedTx) {
+		gas += uint64(len(wireTx)-len(malleatedTx)) * authParams.Params.TxSizeCostPerByte
+	}
+	for _, msg := range wireMsgs {
+		gas += uint64(len(msg.MessageShareCommitment)-1) * paymentParams.Params.GasPerShareCommitment
+	}
+	return uint64(gasAdjustment * float64(gas)), nil
+}
+
+// malleatedMsgs creates the MsgPayForMessages that the MsgWirePayForMessages
+// are malleated into for the square size k
+func malleatedMsgs(wireMsgs []*MsgWirePayForMessage, k uint64) ([]sdktypes.Msg, error) {
+	pfmMsgs := make([]sdktypes.Msg, len(wireMsgs))
+	for i, msg := range wireMsgs {
+		if !msg.commitsTo(k) {
+			return nil, fmt.Errorf("message %d does not commit to square size %d", i, k)
+		}
+		pfmMsg, err := msg.unsignedPayForMessage(k)
+		if err != nil {
+			return nil, err
+		}
+		pfmMsgs[i] = pfmMsg
+	}
+	return pfmMsgs, nil
+}
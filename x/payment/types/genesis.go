@@ -10,6 +10,7 @@ const DefaultIndex uint64 = 1
 // DefaultGenesis returns the default Capability genesis state
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
+		Params: DefaultParams(),
 		// this line is used by starport scaffolding # genesis/types/default
 	}
 }
@@ -19,5 +20,5 @@ func DefaultGenesis() *GenesisState {
 func (gs GenesisState) Validate() error {
 	// this line is used by starport scaffolding # genesis/types/validate
 
-	return nil
+	return gs.Params.Validate()
 }
@@ -4,10 +4,24 @@ import (
 	"testing"
 
 	"github.com/celestiaorg/celestia-app/x/payment/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGenesisState_Validate(t *testing.T) {
+	params := func(minSquareSize, maxSquareSize uint64, requiredSquareSizes ...uint64) types.Params {
+		return types.NewParams(
+			minSquareSize,
+			maxSquareSize,
+			sdk.NewCoin(types.DefaultDenom, sdk.ZeroInt()),
+			sdk.OneDec(),
+			types.DefaultMaxMessageBytes,
+			requiredSquareSizes,
+			types.DefaultGasPerByte,
+			types.DefaultGasPerShareCommitment,
+		)
+	}
+
 	for _, tc := range []struct {
 		desc     string
 		genState *types.GenesisState
@@ -19,12 +33,34 @@ func TestGenesisState_Validate(t *testing.T) {
 			valid:    true,
 		},
 		{
-			desc:     "valid genesis state",
+			desc: "valid genesis state",
 			genState: &types.GenesisState{
+				Params: params(4, 64, 16),
 				// this line is used by starport scaffolding # types/genesis/validField
 			},
 			valid: true,
 		},
+		{
+			desc: "min square size larger than max square size",
+			genState: &types.GenesisState{
+				Params: params(64, 4),
+			},
+			valid: false,
+		},
+		{
+			desc: "square size that is not a power of 2",
+			genState: &types.GenesisState{
+				Params: params(3, 64),
+			},
+			valid: false,
+		},
+		{
+			desc: "required square size larger than max square size",
+			genState: &types.GenesisState{
+				Params: params(4, 64, 128),
+			},
+			valid: false,
+		},
 		// this line is used by starport scaffolding # types/genesis/testcase
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
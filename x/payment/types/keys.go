@@ -20,3 +20,15 @@ const (
 func KeyPrefix(p string) []byte {
 	return []byte(p)
 }
+
+var (
+	// NamespaceStatsPrefix is the store prefix of the NamespaceStats, which are
+	// keyed by namespace id
+	NamespaceStatsPrefix = KeyPrefix("NamespaceStats/")
+	// SignerStatsPrefix is the store prefix of the SignerStats, which are keyed
+	// by signer address
+	SignerStatsPrefix = KeyPrefix("SignerStats/")
+	// MalleatedTxPrefix is the store prefix of the MalleatedTxs, which are
+	// keyed by the hash of the original tx
+	MalleatedTxPrefix = KeyPrefix("MalleatedTx/")
+)
@@ -0,0 +1,285 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+const (
+	// DefaultDenom is the denom of the native token of celestia-app, which is
+	// also the app's bond denom
+	DefaultDenom = "uceles"
+	// DefaultPricePerShare is the default amount of DefaultDenom charged for
+	// each share used by a message
+	DefaultPricePerShare = 1
+	// DefaultMaxMessageBytes is the size of the largest message that fits in
+	// a square of the max size supported by celestia-core
+	DefaultMaxMessageBytes = consts.MaxSquareSize * consts.MaxSquareSize * consts.MsgShareSize
+	// DefaultGasPerByte is the default amount of gas consumed for each byte
+	// of a message
+	DefaultGasPerByte = 8
+	// DefaultGasPerShareCommitment is the default amount of gas consumed for
+	// each share commitment, which is roughly the cost of verifying the
+	// signature it includes
+	DefaultGasPerShareCommitment = 1000
+)
+
+// Parameter store keys
+var (
+	KeyMinSquareSize         = []byte("MinSquareSize")
+	KeyMaxSquareSize         = []byte("MaxSquareSize")
+	KeyPricePerShare         = []byte("PricePerShare")
+	KeyBurnRatio             = []byte("BurnRatio")
+	KeyMaxMessageBytes       = []byte("MaxMessageBytes")
+	KeyRequiredSquareSizes   = []byte("RequiredSquareSizes")
+	KeyGasPerByte            = []byte("GasPerByte")
+	KeyGasPerShareCommitment = []byte("GasPerShareCommitment")
+)
+
+var _ paramtypes.ParamSet = (*Params)(nil)
+
+// ParamKeyTable returns the param key table for the payment module
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams creates a new Params instance
+func NewParams(
+	minSquareSize, maxSquareSize uint64,
+	pricePerShare sdk.Coin,
+	burnRatio sdk.Dec,
+	maxMessageBytes uint64,
+	requiredSquareSizes []uint64,
+	gasPerByte uint64,
+	gasPerShareCommitment uint64,
+) Params {
+	return Params{
+		MinSquareSize:         minSquareSize,
+		MaxSquareSize:         maxSquareSize,
+		PricePerShare:         pricePerShare,
+		BurnRatio:             burnRatio,
+		MaxMessageBytes:       maxMessageBytes,
+		RequiredSquareSizes:   requiredSquareSizes,
+		GasPerByte:            gasPerByte,
+		GasPerShareCommitment: gasPerShareCommitment,
+	}
+}
+
+// DefaultParams returns the default set of payment module parameters. Each
+// share costs DefaultPricePerShare of DefaultDenom, payments are burned, and
+// no square sizes are required.
+func DefaultParams() Params {
+	return NewParams(
+		consts.MinSquareSize,
+		consts.MaxSquareSize,
+		sdk.NewCoin(DefaultDenom, sdk.NewInt(DefaultPricePerShare)),
+		sdk.OneDec(),
+		DefaultMaxMessageBytes,
+		[]uint64{},
+		DefaultGasPerByte,
+		DefaultGasPerShareCommitment,
+	)
+}
+
+// ParamSetPairs fullfills the paramtypes.ParamSet interface
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair(KeyMinSquareSize, &p.MinSquareSize, validateSquareSize),
+		paramtypes.NewParamSetPair(KeyMaxSquareSize, &p.MaxSquareSize, validateSquareSize),
+		paramtypes.NewParamSetPair(KeyPricePerShare, &p.PricePerShare, validatePricePerShare),
+		paramtypes.NewParamSetPair(KeyBurnRatio, &p.BurnRatio, validateBurnRatio),
+		paramtypes.NewParamSetPair(KeyMaxMessageBytes, &p.MaxMessageBytes, validateMaxMessageBytes),
+		paramtypes.NewParamSetPair(KeyRequiredSquareSizes, &p.RequiredSquareSizes, validateRequiredSquareSizes),
+		paramtypes.NewParamSetPair(KeyGasPerByte, &p.GasPerByte, validateGasPerByte),
+		paramtypes.NewParamSetPair(KeyGasPerShareCommitment, &p.GasPerShareCommitment, validateGasPerShareCommitment),
+	}
+}
+
+// Validate checks that each param is valid and that the square sizes are
+// consistent with each other
+func (p Params) Validate() error {
+	if err := validateSquareSize(p.MinSquareSize); err != nil {
+		return err
+	}
+	if err := validateSquareSize(p.MaxSquareSize); err != nil {
+		return err
+	}
+	if err := validatePricePerShare(p.PricePerShare); err != nil {
+		return err
+	}
+	if err := validateBurnRatio(p.BurnRatio); err != nil {
+		return err
+	}
+	if err := validateMaxMessageBytes(p.MaxMessageBytes); err != nil {
+		return err
+	}
+	if err := validateRequiredSquareSizes(p.RequiredSquareSizes); err != nil {
+		return err
+	}
+	if err := validateGasPerByte(p.GasPerByte); err != nil {
+		return err
+	}
+	if err := validateGasPerShareCommitment(p.GasPerShareCommitment); err != nil {
+		return err
+	}
+	if p.MinSquareSize > p.MaxSquareSize {
+		return fmt.Errorf(
+			"min square size (%d) must be less than or equal to max square size (%d)",
+			p.MinSquareSize,
+			p.MaxSquareSize,
+		)
+	}
+	for _, k := range p.RequiredSquareSizes {
+		if k < p.MinSquareSize || k > p.MaxSquareSize {
+			return fmt.Errorf(
+				"required square size %d must be between %d and %d",
+				k,
+				p.MinSquareSize,
+				p.MaxSquareSize,
+			)
+		}
+	}
+	return nil
+}
+
+// PaymentForShares returns the amount that is charged for a message that uses
+// the provided number of shares
+func (p Params) PaymentForShares(shares uint64) sdk.Coin {
+	return sdk.NewCoin(p.PricePerShare.Denom, p.PricePerShare.Amount.Mul(sdk.NewIntFromUint64(shares)))
+}
+
+// SplitPayment splits a payment into the amount that is burned and the amount
+// that is sent to the fee collector, using the burn ratio. The burned amount is
+// rounded down.
+func (p Params) SplitPayment(payment sdk.Coin) (burned, distributed sdk.Coin) {
+	burnAmount := p.BurnRatio.MulInt(payment.Amount).TruncateInt()
+	burned = sdk.NewCoin(payment.Denom, burnAmount)
+	distributed = sdk.NewCoin(payment.Denom, payment.Amount.Sub(burnAmount))
+	return burned, distributed
+}
+
+// GasForMessage returns the minimum amount of gas that is consumed for a
+// message of the provided size that includes the provided number of share
+// commitments
+func (p Params) GasForMessage(messageSize uint64, commitments int) uint64 {
+	return messageSize*p.GasPerByte + uint64(commitments)*p.GasPerShareCommitment
+}
+
+// ValidateWirePayForMessage checks that a MsgWirePayForMessage respects the
+// params: its message must not be larger than the max message bytes, and it
+// must include a share commitment for each of the required square sizes.
+func (p Params) ValidateWirePayForMessage(msg *MsgWirePayForMessage) error {
+	if msg.MessageSize > p.MaxMessageBytes {
+		return sdkerrors.Wrapf(
+			ErrMessageTooLarge,
+			"%d bytes is larger than %d",
+			msg.MessageSize,
+			p.MaxMessageBytes,
+		)
+	}
+	for _, k := range p.RequiredSquareSizes {
+		if !msg.commitsTo(k) {
+			return sdkerrors.Wrapf(ErrMissingCommitment, "square size %d", k)
+		}
+	}
+	return nil
+}
+
+// validateSquareSize ensures that the provided square size is a power of two
+// within the range supported by celestia-core
+func validateSquareSize(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v < consts.MinSquareSize || v > consts.MaxSquareSize {
+		return fmt.Errorf(
+			"square size %d must be between %d and %d",
+			v,
+			consts.MinSquareSize,
+			consts.MaxSquareSize,
+		)
+	}
+	if !IsPowerOf2(v) {
+		return fmt.Errorf("square size %d must be a power of 2", v)
+	}
+	return nil
+}
+
+// validatePricePerShare ensures that the price per share is a valid, non
+// negative amount
+func validatePricePerShare(i interface{}) error {
+	v, ok := i.(sdk.Coin)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if err := v.Validate(); err != nil {
+		return fmt.Errorf("invalid price per share: %w", err)
+	}
+	return nil
+}
+
+// validateBurnRatio ensures that the burn ratio is between zero and one
+func validateBurnRatio(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() {
+		return fmt.Errorf("burn ratio must not be nil")
+	}
+	if v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("burn ratio %s must be between 0 and 1", v)
+	}
+	return nil
+}
+
+// validateMaxMessageBytes ensures that some message can be paid for
+func validateMaxMessageBytes(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == 0 {
+		return fmt.Errorf("max message bytes must be positive")
+	}
+	return nil
+}
+
+// validateRequiredSquareSizes ensures that each required square size is valid
+// and only listed once
+func validateRequiredSquareSizes(i interface{}) error {
+	v, ok := i.([]uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[uint64]bool, len(v))
+	for _, k := range v {
+		if err := validateSquareSize(k); err != nil {
+			return err
+		}
+		if seen[k] {
+			return fmt.Errorf("duplicate required square size %d", k)
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+func validateGasPerByte(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateGasPerShareCommitment(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
@@ -0,0 +1,688 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: payment/params.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Params defines the parameters for the payment module.
+type Params struct {
+	// min_square_size is the smallest original data square width that a block
+	// producer is allowed to select.
+	MinSquareSize uint64 `protobuf:"varint,1,opt,name=min_square_size,json=minSquareSize,proto3" json:"min_square_size,omitempty" yaml:"min_square_size"`
+	// max_square_size is the largest original data square width that a block
+	// producer is allowed to select.
+	MaxSquareSize uint64 `protobuf:"varint,2,opt,name=max_square_size,json=maxSquareSize,proto3" json:"max_square_size,omitempty" yaml:"max_square_size"`
+	// price_per_share is the amount charged to the signer of a
+	// MsgPayForMessage for each share used by its message.
+	PricePerShare types.Coin `protobuf:"bytes,3,opt,name=price_per_share,json=pricePerShare,proto3" json:"price_per_share" yaml:"price_per_share"`
+	// burn_ratio is the fraction of the payments for messages that is burned.
+	// The remainder is sent to the fee collector to be distributed along with
+	// the tx fees.
+	BurnRatio github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,4,opt,name=burn_ratio,json=burnRatio,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"burn_ratio" yaml:"burn_ratio"`
+	// max_message_bytes is the largest message that can be paid for.
+	MaxMessageBytes uint64 `protobuf:"varint,5,opt,name=max_message_bytes,json=maxMessageBytes,proto3" json:"max_message_bytes,omitempty" yaml:"max_message_bytes"`
+	// required_square_sizes are the square sizes that every
+	// MsgWirePayForMessage must include a share commitment for.
+	RequiredSquareSizes []uint64 `protobuf:"varint,6,rep,packed,name=required_square_sizes,json=requiredSquareSizes,proto3" json:"required_square_sizes,omitempty" yaml:"required_square_sizes"`
+	// gas_per_byte is the amount of gas consumed for each byte of a message
+	// that is paid for.
+	GasPerByte uint64 `protobuf:"varint,7,opt,name=gas_per_byte,json=gasPerByte,proto3" json:"gas_per_byte,omitempty" yaml:"gas_per_byte"`
+	// gas_per_share_commitment is the amount of gas consumed for each share
+	// commitment included in a MsgWirePayForMessage or MsgPayForMessage.
+	GasPerShareCommitment uint64 `protobuf:"varint,8,opt,name=gas_per_share_commitment,json=gasPerShareCommitment,proto3" json:"gas_per_share_commitment,omitempty" yaml:"gas_per_share_commitment"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_12d54b052075926a, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetMinSquareSize() uint64 {
+	if m != nil {
+		return m.MinSquareSize
+	}
+	return 0
+}
+
+func (m *Params) GetMaxSquareSize() uint64 {
+	if m != nil {
+		return m.MaxSquareSize
+	}
+	return 0
+}
+
+func (m *Params) GetPricePerShare() types.Coin {
+	if m != nil {
+		return m.PricePerShare
+	}
+	return types.Coin{}
+}
+
+func (m *Params) GetMaxMessageBytes() uint64 {
+	if m != nil {
+		return m.MaxMessageBytes
+	}
+	return 0
+}
+
+func (m *Params) GetRequiredSquareSizes() []uint64 {
+	if m != nil {
+		return m.RequiredSquareSizes
+	}
+	return nil
+}
+
+func (m *Params) GetGasPerByte() uint64 {
+	if m != nil {
+		return m.GasPerByte
+	}
+	return 0
+}
+
+func (m *Params) GetGasPerShareCommitment() uint64 {
+	if m != nil {
+		return m.GasPerShareCommitment
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "payment.Params")
+}
+
+func init() { proto.RegisterFile("payment/params.proto", fileDescriptor_12d54b052075926a) }
+
+var fileDescriptor_12d54b052075926a = []byte{
+	// 489 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x63, 0x9a, 0xa6, 0x74, 0xa1, 0xaa, 0xea, 0xb6, 0x60, 0xa2, 0xca, 0xb6, 0x8c, 0x84,
+	0x72, 0xa9, 0xad, 0xd2, 0x13, 0x1c, 0x1d, 0x0e, 0x5c, 0x2a, 0x45, 0x0e, 0x27, 0x84, 0x64, 0x8d,
+	0xdd, 0x91, 0xbb, 0xa2, 0xeb, 0x75, 0x77, 0x1d, 0x94, 0xf4, 0x29, 0xfa, 0x58, 0x3d, 0xf6, 0x88,
+	0x38, 0x58, 0x28, 0x79, 0x03, 0x3f, 0x01, 0xda, 0x75, 0xfe, 0xd3, 0x53, 0x66, 0xbf, 0x99, 0xfc,
+	0x66, 0xe7, 0x9b, 0x35, 0x39, 0x29, 0x60, 0xc2, 0x30, 0x2f, 0x83, 0x02, 0x04, 0x30, 0xe9, 0x17,
+	0x82, 0x97, 0xdc, 0xdc, 0x9b, 0xab, 0xdd, 0x93, 0x8c, 0x67, 0x5c, 0x6b, 0x81, 0x8a, 0x9a, 0x74,
+	0xd7, 0x4e, 0xb9, 0x64, 0x5c, 0x06, 0x09, 0x48, 0x0c, 0x7e, 0x5d, 0x24, 0x58, 0xc2, 0x45, 0x90,
+	0x72, 0x9a, 0x37, 0x79, 0xef, 0x61, 0x97, 0x74, 0x06, 0x9a, 0x67, 0x86, 0xe4, 0x90, 0xd1, 0x3c,
+	0x96, 0x77, 0x23, 0x10, 0x18, 0x4b, 0x7a, 0x8f, 0x96, 0xe1, 0x1a, 0xbd, 0x76, 0xd8, 0xad, 0x2b,
+	0xe7, 0xcd, 0x04, 0xd8, 0xed, 0x67, 0x6f, 0xab, 0xc0, 0x8b, 0x0e, 0x18, 0xcd, 0x87, 0x5a, 0x18,
+	0xd2, 0x7b, 0xd4, 0x0c, 0x18, 0x6f, 0x30, 0x5e, 0xfc, 0xc7, 0x80, 0xf1, 0x36, 0x03, 0xc6, 0x6b,
+	0x0c, 0x20, 0x87, 0x85, 0xa0, 0x29, 0xc6, 0x05, 0x8a, 0x58, 0xde, 0x80, 0x40, 0x6b, 0xc7, 0x35,
+	0x7a, 0xaf, 0x3e, 0xbe, 0xf3, 0x9b, 0x61, 0x7c, 0x35, 0x8c, 0x3f, 0x1f, 0xc6, 0xef, 0x73, 0x9a,
+	0x87, 0xf6, 0x63, 0xe5, 0xb4, 0x56, 0x2d, 0xb6, 0xfe, 0xef, 0x45, 0x07, 0x5a, 0x19, 0xa0, 0x18,
+	0xaa, 0xb3, 0x99, 0x10, 0x92, 0x8c, 0x44, 0x1e, 0x0b, 0x28, 0x29, 0xb7, 0xda, 0xae, 0xd1, 0xdb,
+	0x0f, 0xfb, 0x0a, 0xf1, 0xa7, 0x72, 0x3e, 0x64, 0xb4, 0xbc, 0x19, 0x25, 0x7e, 0xca, 0x59, 0x30,
+	0x37, 0xaf, 0xf9, 0x39, 0x97, 0xd7, 0x3f, 0x83, 0x72, 0x52, 0xa0, 0xf4, 0xbf, 0x60, 0x5a, 0x57,
+	0xce, 0x51, 0xd3, 0x6c, 0x45, 0xf2, 0xa2, 0x7d, 0x75, 0x88, 0x54, 0x6c, 0x7e, 0x25, 0x47, 0x6a,
+	0x52, 0x86, 0x52, 0x42, 0x86, 0x71, 0x32, 0x29, 0x51, 0x5a, 0xbb, 0xda, 0x8c, 0xb3, 0xba, 0x72,
+	0xac, 0x95, 0x19, 0x1b, 0x25, 0x5e, 0xa4, 0x1c, 0xbc, 0x6a, 0xa4, 0x50, 0x29, 0xe6, 0x37, 0x72,
+	0x2a, 0xf0, 0x6e, 0x44, 0x05, 0x5e, 0xaf, 0x1b, 0x27, 0xad, 0x8e, 0xbb, 0xd3, 0x6b, 0x87, 0x6e,
+	0x5d, 0x39, 0x67, 0x0d, 0xed, 0xd9, 0x32, 0x2f, 0x3a, 0x5e, 0xe8, 0x2b, 0x97, 0xa5, 0xf9, 0x89,
+	0xbc, 0xce, 0x40, 0x6a, 0x93, 0x54, 0x63, 0x6b, 0x4f, 0x5f, 0xed, 0x6d, 0x5d, 0x39, 0xc7, 0x0d,
+	0x6c, 0x3d, 0xeb, 0x45, 0x24, 0x03, 0x39, 0x40, 0xa1, 0x6e, 0x64, 0xfe, 0x20, 0xd6, 0x22, 0xa9,
+	0xfd, 0x8d, 0x53, 0xce, 0x18, 0x2d, 0xd5, 0x33, 0xb4, 0x5e, 0x6a, 0xcc, 0xfb, 0xba, 0x72, 0x9c,
+	0x4d, 0xcc, 0x76, 0xa5, 0x17, 0x9d, 0x36, 0x48, 0xbd, 0x92, 0xfe, 0x52, 0x0f, 0xaf, 0x1e, 0xa7,
+	0xb6, 0xf1, 0x34, 0xb5, 0x8d, 0xbf, 0x53, 0xdb, 0x78, 0x98, 0xd9, 0xad, 0xa7, 0x99, 0xdd, 0xfa,
+	0x3d, 0xb3, 0x5b, 0xdf, 0x2f, 0xd7, 0x57, 0x83, 0xb7, 0x28, 0x4b, 0x0a, 0x5c, 0x64, 0xcb, 0xf8,
+	0x1c, 0x8a, 0x22, 0x18, 0x07, 0x8b, 0xef, 0x44, 0xef, 0x2a, 0xe9, 0xe8, 0x87, 0x7e, 0xf9, 0x6f,
+	0x00, 0x4f, 0x41, 0x0d, 0xb5, 0x3f, 0x03, 0x00, 0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GasPerShareCommitment != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.GasPerShareCommitment))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.GasPerByte != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.GasPerByte))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.RequiredSquareSizes) > 0 {
+		dAtA2 := make([]byte, len(m.RequiredSquareSizes)*10)
+		var j1 int
+		for _, num := range m.RequiredSquareSizes {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintParams(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.MaxMessageBytes != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxMessageBytes))
+		i--
+		dAtA[i] = 0x28
+	}
+	{
+		size := m.BurnRatio.Size()
+		i -= size
+		if _, err := m.BurnRatio.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.PricePerShare.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.MaxSquareSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxSquareSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MinSquareSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MinSquareSize))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
+	offset -= sovParams(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinSquareSize != 0 {
+		n += 1 + sovParams(uint64(m.MinSquareSize))
+	}
+	if m.MaxSquareSize != 0 {
+		n += 1 + sovParams(uint64(m.MaxSquareSize))
+	}
+	l = m.PricePerShare.Size()
+	n += 1 + l + sovParams(uint64(l))
+	l = m.BurnRatio.Size()
+	n += 1 + l + sovParams(uint64(l))
+	if m.MaxMessageBytes != 0 {
+		n += 1 + sovParams(uint64(m.MaxMessageBytes))
+	}
+	if len(m.RequiredSquareSizes) > 0 {
+		l = 0
+		for _, e := range m.RequiredSquareSizes {
+			l += sovParams(uint64(e))
+		}
+		n += 1 + sovParams(uint64(l)) + l
+	}
+	if m.GasPerByte != 0 {
+		n += 1 + sovParams(uint64(m.GasPerByte))
+	}
+	if m.GasPerShareCommitment != 0 {
+		n += 1 + sovParams(uint64(m.GasPerShareCommitment))
+	}
+	return n
+}
+
+func sovParams(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozParams(x uint64) (n int) {
+	return sovParams(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinSquareSize", wireType)
+			}
+			m.MinSquareSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinSquareSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxSquareSize", wireType)
+			}
+			m.MaxSquareSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxSquareSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PricePerShare", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.PricePerShare.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BurnRatio", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BurnRatio.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMessageBytes", wireType)
+			}
+			m.MaxMessageBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMessageBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowParams
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RequiredSquareSizes = append(m.RequiredSquareSizes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowParams
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthParams
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthParams
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RequiredSquareSizes) == 0 {
+					m.RequiredSquareSizes = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowParams
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RequiredSquareSizes = append(m.RequiredSquareSizes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiredSquareSizes", wireType)
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPerByte", wireType)
+			}
+			m.GasPerByte = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasPerByte |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPerShareCommitment", wireType)
+			}
+			m.GasPerShareCommitment = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasPerShareCommitment |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipParams(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthParams
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupParams
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthParams
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthParams        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowParams          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupParams = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,232 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/pkg/consts"
+)
+
+func TestParamsValidate(t *testing.T) {
+	type test struct {
+		name      string
+		mutate    func(*Params)
+		expectErr bool
+	}
+	tests := []test{
+		{
+			name:   "default params",
+			mutate: func(*Params) {},
+		},
+		{
+			name: "equal min and max",
+			mutate: func(p *Params) {
+				p.MinSquareSize, p.MaxSquareSize = 16, 16
+			},
+		},
+		{
+			name: "zero min square size",
+			mutate: func(p *Params) {
+				p.MinSquareSize = 0
+			},
+			expectErr: true,
+		},
+		{
+			name: "max square size larger than celestia-core allows",
+			mutate: func(p *Params) {
+				p.MaxSquareSize = consts.MaxSquareSize * 2
+			},
+			expectErr: true,
+		},
+		{
+			name: "max square size not a power of 2",
+			mutate: func(p *Params) {
+				p.MaxSquareSize = 100
+			},
+			expectErr: true,
+		},
+		{
+			name: "min larger than max",
+			mutate: func(p *Params) {
+				p.MinSquareSize, p.MaxSquareSize = 32, 16
+			},
+			expectErr: true,
+		},
+		{
+			name: "payments split between burning and the fee collector",
+			mutate: func(p *Params) {
+				p.PricePerShare = sdk.NewCoin(DefaultDenom, sdk.NewInt(5))
+				p.BurnRatio = sdk.NewDecWithPrec(5, 1)
+			},
+		},
+		{
+			name: "negative price per share",
+			mutate: func(p *Params) {
+				p.PricePerShare = sdk.Coin{Denom: DefaultDenom, Amount: sdk.NewInt(-1)}
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid price per share denom",
+			mutate: func(p *Params) {
+				p.PricePerShare = sdk.Coin{Denom: "!", Amount: sdk.NewInt(1)}
+			},
+			expectErr: true,
+		},
+		{
+			name: "burn ratio larger than one",
+			mutate: func(p *Params) {
+				p.BurnRatio = sdk.NewDecWithPrec(11, 1)
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative burn ratio",
+			mutate: func(p *Params) {
+				p.BurnRatio = sdk.NewDec(-1)
+			},
+			expectErr: true,
+		},
+		{
+			name: "zero max message bytes",
+			mutate: func(p *Params) {
+				p.MaxMessageBytes = 0
+			},
+			expectErr: true,
+		},
+		{
+			name: "required square sizes",
+			mutate: func(p *Params) {
+				p.RequiredSquareSizes = []uint64{16, 32}
+			},
+		},
+		{
+			name: "duplicate required square size",
+			mutate: func(p *Params) {
+				p.RequiredSquareSizes = []uint64{16, 16}
+			},
+			expectErr: true,
+		},
+		{
+			name: "required square size outside of the square size bounds",
+			mutate: func(p *Params) {
+				p.MaxSquareSize = 32
+				p.RequiredSquareSizes = []uint64{64}
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		params := DefaultParams()
+		tt.mutate(&params)
+		err := params.Validate()
+		if tt.expectErr {
+			assert.Error(t, err, tt.name)
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+	}
+}
+
+func TestPaymentForShares(t *testing.T) {
+	params := DefaultParams()
+	params.PricePerShare = sdk.NewCoin("token", sdk.NewInt(3))
+	assert.Equal(t, sdk.NewCoin("token", sdk.NewInt(0)), params.PaymentForShares(0))
+	assert.Equal(t, sdk.NewCoin("token", sdk.NewInt(12)), params.PaymentForShares(4))
+}
+
+func TestSplitPayment(t *testing.T) {
+	type test struct {
+		name                string
+		burnRatio           sdk.Dec
+		payment             int64
+		burned, distributed int64
+	}
+	tests := []test{
+		{"burn everything", sdk.OneDec(), 10, 10, 0},
+		{"distribute everything", sdk.ZeroDec(), 10, 0, 10},
+		{"burned amount is rounded down", sdk.NewDecWithPrec(5, 1), 7, 3, 4},
+	}
+	for _, tt := range tests {
+		params := DefaultParams()
+		params.BurnRatio = tt.burnRatio
+		burned, distributed := params.SplitPayment(sdk.NewCoin("token", sdk.NewInt(tt.payment)))
+		assert.Equal(t, tt.burned, burned.Amount.Int64(), tt.name)
+		assert.Equal(t, tt.distributed, distributed.Amount.Int64(), tt.name)
+	}
+}
+
+func TestGasForMessage(t *testing.T) {
+	type test struct {
+		name                              string
+		gasPerByte, gasPerShareCommitment uint64
+		messageSize                       uint64
+		commitments                       int
+		expected                          uint64
+	}
+	tests := []test{
+		{"no gas", 0, 0, 512, 4, 0},
+		{"only bytes", 8, 0, 512, 4, 4096},
+		{"only commitments", 0, 1000, 512, 4, 4000},
+		{"bytes and commitments", 8, 1000, 512, 1, 5096},
+	}
+	for _, tt := range tests {
+		params := DefaultParams()
+		params.GasPerByte = tt.gasPerByte
+		params.GasPerShareCommitment = tt.gasPerShareCommitment
+		assert.Equal(t, tt.expected, params.GasForMessage(tt.messageSize, tt.commitments), tt.name)
+	}
+}
+
+func TestValidateWirePayForMessage(t *testing.T) {
+	msg := &MsgWirePayForMessage{
+		MessageSize: 100,
+		MessageShareCommitment: []ShareCommitAndSignature{
+			{K: 16},
+			{K: 32},
+		},
+	}
+
+	type test struct {
+		name     string
+		mutate   func(*Params)
+		expected error
+	}
+	tests := []test{
+		{
+			name:   "default params",
+			mutate: func(*Params) {},
+		},
+		{
+			name: "message is too large",
+			mutate: func(p *Params) {
+				p.MaxMessageBytes = 99
+			},
+			expected: ErrMessageTooLarge,
+		},
+		{
+			name: "commits to the required square sizes",
+			mutate: func(p *Params) {
+				p.RequiredSquareSizes = []uint64{32}
+			},
+		},
+		{
+			name: "missing a required square size",
+			mutate: func(p *Params) {
+				p.RequiredSquareSizes = []uint64{32, 64}
+			},
+			expected: ErrMissingCommitment,
+		},
+	}
+	for _, tt := range tests {
+		params := DefaultParams()
+		tt.mutate(&params)
+		err := params.ValidateWirePayForMessage(msg)
+		if tt.expected == nil {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		assert.ErrorIs(t, err, tt.expected, tt.name)
+	}
+}
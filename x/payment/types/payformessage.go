@@ -1,15 +1,13 @@
 package types
 
 import (
-	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 
-	"github.com/celestiaorg/nmt"
 	sdkclient "github.com/cosmos/cosmos-sdk/client"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
-	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/pkg/consts"
 )
 
@@ -68,19 +66,26 @@ func (msg *MsgPayForMessage) GetSigners() []sdk.AccAddress {
 
 // BuildPayForMessageTxFromWireTx creates an authsigning.Tx using data from the original
 // MsgWirePayForMessage sdk.Tx and the signature provided. This is used while processing
-// the MsgWirePayForMessages into Signed  MsgPayForMessage
+// the MsgWirePayForMessages into Signed  MsgPayForMessage. A MsgPayForMessage must be
+// provided for each MsgWirePayForMessage in the original tx, in the same order.
 func BuildPayForMessageTxFromWireTx(
 	origTx authsigning.Tx,
 	builder sdkclient.TxBuilder,
 	signature []byte,
-	msg *MsgPayForMessage,
+	msgs ...*MsgPayForMessage,
 ) (authsigning.Tx, error) {
-	err := builder.SetMsgs(msg)
+	sdkMsgs := make([]sdk.Msg, len(msgs))
+	for i, msg := range msgs {
+		sdkMsgs[i] = msg
+	}
+	err := builder.SetMsgs(sdkMsgs...)
 	if err != nil {
 		return nil, err
 	}
 	builder.SetGasLimit(origTx.GetGas())
 	builder.SetFeeAmount(origTx.GetFee())
+	// the fee granter is covered by the signature, so it is kept as well
+	builder.SetFeeGranter(origTx.FeeGranter())
 
 	origSigs, err := origTx.GetSignaturesV2()
 	if err != nil {
@@ -111,57 +116,73 @@ func BuildPayForMessageTxFromWireTx(
 // squaresize using a namespace merkle tree and the rules described at
 // https://github.com/celestiaorg/celestia-specs/blob/master/src/rationale/message_block_layout.md#message-layout-rationale
 func CreateCommitment(k uint64, namespace, message []byte) ([]byte, error) {
-	// add padding to the message if necessary
-	message = padMessage(message)
-
-	// break message into shares
-	shares := chunkMessage(message)
-	// if the number of shares is larger than that in the square, throw an error
-	// note, we use k*k-1 here because at least a single share will be reserved
-	// for the transaction paying for the message, therefore the max number of
-	// shares a message can be is number of shares in square -1.
-	if uint64(len(shares)) > k*k-1 {
-		return nil, fmt.Errorf("message size exceeds square size")
-	}
-
-	// organize shares for merkle mountain range
-	heights := powerOf2MountainRange(uint64(len(shares)), k)
-	leafSets := make([][][]byte, len(heights))
-	cursor := uint64(0)
-	for i, height := range heights {
-		leafSets[i] = shares[cursor : cursor+height]
-		cursor = cursor + height
-	}
-
-	// create the commits by pushing each leaf set onto an nmt
-	subTreeRoots := make([][]byte, len(leafSets))
-	for i, set := range leafSets {
-		// create the nmt todo(evan) use nmt wrapper
-		tree := nmt.New(sha256.New(), nmt.NamespaceIDSize(NamespaceIDSize))
-		for _, leaf := range set {
-			nsLeaf := append(make([]byte, 0), append(namespace, leaf...)...)
-			err := tree.Push(nsLeaf)
-			if err != nil {
-				return nil, err
-			}
-		}
-		// add the root
-		subTreeRoots[i] = tree.Root()
+	// add padding to the message if necessary, and break it into the shares
+	// that celestia-core lays it out in
+	shares := MessageToShares(namespace, message)
+
+	// organize shares for merkle mountain range. NewCommitmentVerifier throws
+	// an error if the number of shares is larger than k*k-1, because at least a
+	// single share will be reserved for the transaction paying for the message,
+	// therefore the max number of shares a message can be is number of shares
+	// in square -1.
+	v, err := newCommitmentVerifier(k, namespace, uint64(len(shares)))
+	if err != nil {
+		return nil, err
+	}
+
+	// create the commits by hashing each leaf set into an nmt
+	subTreeRoots, err := v.SubtreeRoots(shares)
+	if err != nil {
+		return nil, err
 	}
-	return merkle.HashFromByteSlices(subTreeRoots), nil
+	return v.Commitment(subTreeRoots), nil
 }
 
-// chunkMessage breaks the message into ShareSize pieces
-func chunkMessage(message []byte) [][]byte {
-	var shares [][]byte
-	for i := 0; i < len(message); i += ShareSize {
-		end := i + ShareSize
-		if end > len(message) {
-			end = len(message)
+// AllSquareSizes returns every power of two square size supported by
+// celestia-core that is large enough to fit a message of the provided size
+func AllSquareSizes(msgSize int) []uint64 {
+	shareCount := MessageShares(PaddedMessageSize(uint64(msgSize)))
+	var sizes []uint64
+	for k := uint64(consts.MinSquareSize); k <= consts.MaxSquareSize; k *= 2 {
+		// see CreateCommitment for why a share is reserved
+		if shareCount > k*k-1 {
+			continue
 		}
-		shares = append(shares, message[i:end])
+		sizes = append(sizes, k)
+	}
+	return sizes
+}
+
+// SquareSizeForShares returns the size of the square that celestia-core builds
+// for block data that takes up the provided number of shares, which is the
+// smallest power of two whose square fits all of the shares
+func SquareSizeForShares(shares uint64) uint64 {
+	k := uint64(consts.MinSquareSize)
+	for k*k < shares {
+		k *= 2
 	}
-	return shares
+	return k
+}
+
+// IsPowerOf2 checks if the provided number is a power of two
+func IsPowerOf2(v uint64) bool {
+	return v != 0 && v&(v-1) == 0
+}
+
+// MessageSubtreeHeights returns the heights of the subtrees of the merkle
+// mountain range that CreateCommitment uses for a message of the provided size
+// in a square of size k. Each height is the number of shares in the subtree.
+func MessageSubtreeHeights(k uint64, msgSize uint64) []uint64 {
+	return powerOf2MountainRange(MessageShares(PaddedMessageSize(msgSize)), k)
+}
+
+// MessageShares returns the number of shares that celestia-core uses to store
+// a message of the provided size, as each message is prefixed with its
+// uvarint encoded length before being split into shares
+func MessageShares(msgSize uint64) uint64 {
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	delimitedSize := uint64(binary.PutUvarint(lenBuf, msgSize)) + msgSize
+	return (delimitedSize + consts.MsgShareSize - 1) / consts.MsgShareSize
 }
 
 // padMessage adds padding to the msg if the length of the msg is not divisible
@@ -172,13 +193,17 @@ func padMessage(msg []byte) []byte {
 		return msg
 	}
 
-	shareCount := (len(msg) / ShareSize) + 1
-
-	padded := make([]byte, shareCount*ShareSize)
+	padded := make([]byte, PaddedMessageSize(uint64(len(msg))))
 	copy(padded, msg)
 	return padded
 }
 
+// PaddedMessageSize returns the size of a message of the provided size once it
+// is padded by NewWirePayForMessage to a multiple of the share size
+func PaddedMessageSize(msgSize uint64) uint64 {
+	return (msgSize + ShareSize - 1) / ShareSize * ShareSize
+}
+
 // powerOf2MountainRange returns the heights of the subtrees for binary merkle
 // mountian range
 func powerOf2MountainRange(l, k uint64) []uint64 {
@@ -42,6 +42,23 @@ func TestMountainRange(t *testing.T) {
 	}
 }
 
+func TestMessageSubtreeHeights(t *testing.T) {
+	type test struct {
+		k, msgSize uint64
+		expected   []uint64
+	}
+	tests := []test{
+		{k: 4, msgSize: ShareSize, expected: []uint64{2}},
+		{k: 4, msgSize: 11 * ShareSize, expected: []uint64{4, 4, 4}},
+		// messages are padded to a multiple of the share size, and prefixed
+		// with their length
+		{k: 8, msgSize: 2*ShareSize + 1, expected: []uint64{4}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, MessageSubtreeHeights(tt.k, tt.msgSize))
+	}
+}
+
 func TestNextPowerOf2(t *testing.T) {
 	type test struct {
 		input    uint64
@@ -90,7 +107,7 @@ func TestCreateCommitment(t *testing.T) {
 			k:         4,
 			namespace: bytes.Repeat([]byte{0xFF}, 8),
 			message:   bytes.Repeat([]byte{0xFF}, 11*ShareSize),
-			expected:  []byte{0x1c, 0x57, 0x89, 0x2f, 0xbe, 0xbf, 0xa2, 0xa4, 0x4c, 0x41, 0x9e, 0x2d, 0x88, 0xd5, 0x87, 0xc0, 0xbd, 0x37, 0xc0, 0x85, 0xbd, 0x10, 0x3c, 0x36, 0xd9, 0xa2, 0x4d, 0x4e, 0x31, 0xa2, 0xf8, 0x4e},
+			expected:  []byte{0xf2, 0xd4, 0xfc, 0x39, 0x4e, 0xf3, 0x97, 0x9d, 0xf4, 0x4c, 0x99, 0x87, 0x36, 0x7d, 0x7d, 0x4, 0xf2, 0xa7, 0x89, 0x26, 0x6d, 0xf5, 0x78, 0xe1, 0xff, 0x72, 0xb4, 0x75, 0x12, 0x1e, 0x71, 0xc3},
 		},
 		{
 			k:         2,
@@ -110,6 +127,48 @@ func TestCreateCommitment(t *testing.T) {
 	}
 }
 
+func TestAllSquareSizes(t *testing.T) {
+	type test struct {
+		msgSize  int
+		expected []uint64
+	}
+	tests := []test{
+		{
+			msgSize:  0,
+			expected: []uint64{2, 4, 8, 16, 32, 64, 128},
+		},
+		{
+			msgSize:  1,
+			expected: []uint64{2, 4, 8, 16, 32, 64, 128},
+		},
+		{
+			msgSize:  16 * ShareSize,
+			expected: []uint64{8, 16, 32, 64, 128},
+		},
+	}
+	for _, tt := range tests {
+		res := AllSquareSizes(tt.msgSize)
+		assert.Equal(t, tt.expected, res)
+	}
+}
+
+func TestSquareSizeForShares(t *testing.T) {
+	type test struct {
+		shares   uint64
+		expected uint64
+	}
+	tests := []test{
+		{shares: 0, expected: 1},
+		{shares: 1, expected: 1},
+		{shares: 2, expected: 2},
+		{shares: 16, expected: 4},
+		{shares: 17, expected: 8},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, SquareSizeForShares(tt.shares), tt.shares)
+	}
+}
+
 func TestPadMessage(t *testing.T) {
 	type test struct {
 		input    []byte
@@ -318,7 +377,7 @@ func TestProcessMessage(t *testing.T) {
 
 	tests := []test{
 		{
-			name:   "single share square size 2",
+			name:   "two shares square size 2",
 			ns:     []byte{1, 1, 1, 1, 1, 1, 1, 1},
 			msg:    bytes.Repeat([]byte{1}, ShareSize),
 			ss:     2,
@@ -327,14 +386,14 @@ func TestProcessMessage(t *testing.T) {
 		{
 			name:   "15 shares square size 4",
 			ns:     []byte{1, 1, 1, 1, 1, 1, 1, 2},
-			msg:    bytes.Repeat([]byte{2}, ShareSize*15),
+			msg:    bytes.Repeat([]byte{2}, ShareSize*14),
 			ss:     4,
 			modify: dontModify,
 		},
 		{
 			name: "",
 			ns:   []byte{1, 1, 1, 1, 1, 1, 1, 2},
-			msg:  bytes.Repeat([]byte{2}, ShareSize*15),
+			msg:  bytes.Repeat([]byte{2}, ShareSize*14),
 			ss:   4,
 			modify: func(wpfm *MsgWirePayForMessage) *MsgWirePayForMessage {
 				wpfm.MessageShareCommitment[0].K = 99999
@@ -368,6 +427,60 @@ func TestProcessMessage(t *testing.T) {
 	}
 }
 
+func TestSignWirePayForMessages(t *testing.T) {
+	kb := generateKeyring(t, "test")
+	signer := NewKeyringSigner(kb, "test", "chain-id")
+	options := []TxBuilderOption{SetGasLimit(2000000)}
+	sizes := []uint64{4, 8, 16}
+
+	first, err := NewWirePayForMessage([]byte{1, 1, 1, 1, 1, 1, 1, 1}, bytes.Repeat([]byte{1}, ShareSize), sizes...)
+	require.NoError(t, err)
+	second, err := NewWirePayForMessage([]byte{2, 2, 2, 2, 2, 2, 2, 2}, bytes.Repeat([]byte{2}, ShareSize*3), sizes...)
+	require.NoError(t, err)
+	wpfms := []*MsgWirePayForMessage{first, second}
+
+	err = SignWirePayForMessages(signer, wpfms, options...)
+	require.NoError(t, err)
+
+	for _, size := range sizes {
+		messages, pfms, sig, err := ProcessWirePayForMessages(wpfms, size)
+		require.NoError(t, err)
+		require.Len(t, messages, 2)
+		require.Len(t, pfms, 2)
+		assert.Equal(t, first.Message, messages[0].Data)
+		assert.Equal(t, second.Message, messages[1].Data)
+
+		// the signature is over the tx containing both PayForMessages
+		builder := applyOptions(signer.NewTxBuilder(), options...)
+		tx, err := signer.BuildSignedTx(builder, pfms[0], pfms[1])
+		require.NoError(t, err)
+
+		bytesToSign, err := signer.encCfg.TxConfig.SignModeHandler().GetSignBytes(
+			signing.SignMode_SIGN_MODE_DIRECT,
+			authsigning.SignerData{
+				ChainID:       signer.chainID,
+				AccountNumber: signer.accountNumber,
+				Sequence:      signer.sequence,
+			},
+			tx,
+		)
+		require.NoError(t, err)
+		assert.True(t, signer.GetSignerInfo().GetPubKey().VerifySignature(bytesToSign, sig), size)
+	}
+
+	// msgs that are signed separately can't be processed together
+	err = first.SignShareCommitments(signer, options...)
+	require.NoError(t, err)
+	_, _, _, err = ProcessWirePayForMessages(wpfms, sizes[0])
+	assert.Error(t, err)
+
+	// every msg must commit to the same square sizes
+	third, err := NewWirePayForMessage([]byte{3, 3, 3, 3, 3, 3, 3, 3}, []byte{3}, 4, 8)
+	require.NoError(t, err)
+	err = SignWirePayForMessages(signer, []*MsgWirePayForMessage{first, third}, options...)
+	assert.Error(t, err)
+}
+
 func validWirePayForMessage(t *testing.T) *MsgWirePayForMessage {
 	msg, err := NewWirePayForMessage(
 		[]byte{1, 2, 3, 4, 5, 6, 7, 8},
@@ -7,11 +7,16 @@ import (
 	context "context"
 	fmt "fmt"
 	_ "github.com/cosmos/cosmos-sdk/types/query"
+	_ "github.com/gogo/protobuf/gogoproto"
 	grpc1 "github.com/gogo/protobuf/grpc"
 	proto "github.com/gogo/protobuf/proto"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
 	math "math"
+	math_bits "math/bits"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -25,22 +30,656 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// QueryParamsRequest is the request type for the Query/Params RPC method.
+type QueryParamsRequest struct {
+}
+
+func (m *QueryParamsRequest) Reset()         { *m = QueryParamsRequest{} }
+func (m *QueryParamsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryParamsRequest) ProtoMessage()    {}
+func (*QueryParamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{0}
+}
+func (m *QueryParamsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryParamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryParamsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryParamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryParamsRequest.Merge(m, src)
+}
+func (m *QueryParamsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryParamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryParamsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryParamsRequest proto.InternalMessageInfo
+
+// QueryParamsResponse is the response type for the Query/Params RPC method.
+type QueryParamsResponse struct {
+	// params holds all the parameters of the payment module.
+	Params Params `protobuf:"bytes,1,opt,name=params,proto3" json:"params"`
+}
+
+func (m *QueryParamsResponse) Reset()         { *m = QueryParamsResponse{} }
+func (m *QueryParamsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryParamsResponse) ProtoMessage()    {}
+func (*QueryParamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{1}
+}
+func (m *QueryParamsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryParamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryParamsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryParamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryParamsResponse.Merge(m, src)
+}
+func (m *QueryParamsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryParamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryParamsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryParamsResponse proto.InternalMessageInfo
+
+func (m *QueryParamsResponse) GetParams() Params {
+	if m != nil {
+		return m.Params
+	}
+	return Params{}
+}
+
+// QueryNamespaceStatsRequest is the request type for the Query/NamespaceStats
+// RPC method.
+type QueryNamespaceStatsRequest struct {
+	// namespace_id is the hex encoded namespace id.
+	NamespaceId string `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+}
+
+func (m *QueryNamespaceStatsRequest) Reset()         { *m = QueryNamespaceStatsRequest{} }
+func (m *QueryNamespaceStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryNamespaceStatsRequest) ProtoMessage()    {}
+func (*QueryNamespaceStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{2}
+}
+func (m *QueryNamespaceStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryNamespaceStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryNamespaceStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryNamespaceStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryNamespaceStatsRequest.Merge(m, src)
+}
+func (m *QueryNamespaceStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryNamespaceStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryNamespaceStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryNamespaceStatsRequest proto.InternalMessageInfo
+
+func (m *QueryNamespaceStatsRequest) GetNamespaceId() string {
+	if m != nil {
+		return m.NamespaceId
+	}
+	return ""
+}
+
+// QueryNamespaceStatsResponse is the response type for the
+// Query/NamespaceStats RPC method.
+type QueryNamespaceStatsResponse struct {
+	Stats NamespaceStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats"`
+}
+
+func (m *QueryNamespaceStatsResponse) Reset()         { *m = QueryNamespaceStatsResponse{} }
+func (m *QueryNamespaceStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryNamespaceStatsResponse) ProtoMessage()    {}
+func (*QueryNamespaceStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{3}
+}
+func (m *QueryNamespaceStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryNamespaceStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryNamespaceStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryNamespaceStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryNamespaceStatsResponse.Merge(m, src)
+}
+func (m *QueryNamespaceStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryNamespaceStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryNamespaceStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryNamespaceStatsResponse proto.InternalMessageInfo
+
+func (m *QueryNamespaceStatsResponse) GetStats() NamespaceStats {
+	if m != nil {
+		return m.Stats
+	}
+	return NamespaceStats{}
+}
+
+// QuerySignerStatsRequest is the request type for the Query/SignerStats RPC
+// method.
+type QuerySignerStatsRequest struct {
+	// signer is the bech32 encoded address of the signer.
+	Signer string `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+}
+
+func (m *QuerySignerStatsRequest) Reset()         { *m = QuerySignerStatsRequest{} }
+func (m *QuerySignerStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*QuerySignerStatsRequest) ProtoMessage()    {}
+func (*QuerySignerStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{4}
+}
+func (m *QuerySignerStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuerySignerStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuerySignerStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuerySignerStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuerySignerStatsRequest.Merge(m, src)
+}
+func (m *QuerySignerStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuerySignerStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuerySignerStatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuerySignerStatsRequest proto.InternalMessageInfo
+
+func (m *QuerySignerStatsRequest) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+// QuerySignerStatsResponse is the response type for the Query/SignerStats RPC
+// method.
+type QuerySignerStatsResponse struct {
+	Stats SignerStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats"`
+}
+
+func (m *QuerySignerStatsResponse) Reset()         { *m = QuerySignerStatsResponse{} }
+func (m *QuerySignerStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*QuerySignerStatsResponse) ProtoMessage()    {}
+func (*QuerySignerStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{5}
+}
+func (m *QuerySignerStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuerySignerStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuerySignerStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuerySignerStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuerySignerStatsResponse.Merge(m, src)
+}
+func (m *QuerySignerStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuerySignerStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuerySignerStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuerySignerStatsResponse proto.InternalMessageInfo
+
+func (m *QuerySignerStatsResponse) GetStats() SignerStats {
+	if m != nil {
+		return m.Stats
+	}
+	return SignerStats{}
+}
+
+// QueryCommitmentRequest is the request type for the Query/Commitment RPC
+// method.
+type QueryCommitmentRequest struct {
+	// namespace_id is the hex encoded namespace id of the message.
+	NamespaceId string `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	Message     []byte `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	SquareSize  uint64 `protobuf:"varint,3,opt,name=square_size,json=squareSize,proto3" json:"square_size,omitempty"`
+}
+
+func (m *QueryCommitmentRequest) Reset()         { *m = QueryCommitmentRequest{} }
+func (m *QueryCommitmentRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryCommitmentRequest) ProtoMessage()    {}
+func (*QueryCommitmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{6}
+}
+func (m *QueryCommitmentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryCommitmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryCommitmentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryCommitmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCommitmentRequest.Merge(m, src)
+}
+func (m *QueryCommitmentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryCommitmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCommitmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCommitmentRequest proto.InternalMessageInfo
+
+func (m *QueryCommitmentRequest) GetNamespaceId() string {
+	if m != nil {
+		return m.NamespaceId
+	}
+	return ""
+}
+
+func (m *QueryCommitmentRequest) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *QueryCommitmentRequest) GetSquareSize() uint64 {
+	if m != nil {
+		return m.SquareSize
+	}
+	return 0
+}
+
+// QueryCommitmentResponse is the response type for the Query/Commitment RPC
+// method.
+type QueryCommitmentResponse struct {
+	ShareCommitment []byte `protobuf:"bytes,1,opt,name=share_commitment,json=shareCommitment,proto3" json:"share_commitment,omitempty"`
+	// message_size is the size of the message after it is padded to a multiple
+	// of the share size, which is the size declared by a MsgWirePayForMessage.
+	MessageSize uint64 `protobuf:"varint,2,opt,name=message_size,json=messageSize,proto3" json:"message_size,omitempty"`
+	// shares is the number of shares used by the message.
+	Shares uint64 `protobuf:"varint,3,opt,name=shares,proto3" json:"shares,omitempty"`
+}
+
+func (m *QueryCommitmentResponse) Reset()         { *m = QueryCommitmentResponse{} }
+func (m *QueryCommitmentResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryCommitmentResponse) ProtoMessage()    {}
+func (*QueryCommitmentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{7}
+}
+func (m *QueryCommitmentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryCommitmentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryCommitmentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryCommitmentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCommitmentResponse.Merge(m, src)
+}
+func (m *QueryCommitmentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryCommitmentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCommitmentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCommitmentResponse proto.InternalMessageInfo
+
+func (m *QueryCommitmentResponse) GetShareCommitment() []byte {
+	if m != nil {
+		return m.ShareCommitment
+	}
+	return nil
+}
+
+func (m *QueryCommitmentResponse) GetMessageSize() uint64 {
+	if m != nil {
+		return m.MessageSize
+	}
+	return 0
+}
+
+func (m *QueryCommitmentResponse) GetShares() uint64 {
+	if m != nil {
+		return m.Shares
+	}
+	return 0
+}
+
+// QueryRejectionRequest is the request type for the Query/Rejection RPC method.
+type QueryRejectionRequest struct {
+	// tx_hash is the hex encoded hash of the tx.
+	TxHash string `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (m *QueryRejectionRequest) Reset()         { *m = QueryRejectionRequest{} }
+func (m *QueryRejectionRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryRejectionRequest) ProtoMessage()    {}
+func (*QueryRejectionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{8}
+}
+func (m *QueryRejectionRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRejectionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRejectionRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryRejectionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRejectionRequest.Merge(m, src)
+}
+func (m *QueryRejectionRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRejectionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRejectionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRejectionRequest proto.InternalMessageInfo
+
+func (m *QueryRejectionRequest) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+// QueryRejectionResponse is the response type for the Query/Rejection RPC
+// method.
+type QueryRejectionResponse struct {
+	Rejection *Rejection `protobuf:"bytes,1,opt,name=rejection,proto3" json:"rejection,omitempty"`
+}
+
+func (m *QueryRejectionResponse) Reset()         { *m = QueryRejectionResponse{} }
+func (m *QueryRejectionResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryRejectionResponse) ProtoMessage()    {}
+func (*QueryRejectionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{9}
+}
+func (m *QueryRejectionResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryRejectionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryRejectionResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryRejectionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRejectionResponse.Merge(m, src)
+}
+func (m *QueryRejectionResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryRejectionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRejectionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryRejectionResponse proto.InternalMessageInfo
+
+func (m *QueryRejectionResponse) GetRejection() *Rejection {
+	if m != nil {
+		return m.Rejection
+	}
+	return nil
+}
+
+// QueryMalleatedTxRequest is the request type for the Query/MalleatedTx RPC
+// method.
+type QueryMalleatedTxRequest struct {
+	// parent_hash is the hex encoded hash of the tx that was broadcast.
+	ParentHash string `protobuf:"bytes,1,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+}
+
+func (m *QueryMalleatedTxRequest) Reset()         { *m = QueryMalleatedTxRequest{} }
+func (m *QueryMalleatedTxRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryMalleatedTxRequest) ProtoMessage()    {}
+func (*QueryMalleatedTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{10}
+}
+func (m *QueryMalleatedTxRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryMalleatedTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryMalleatedTxRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryMalleatedTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryMalleatedTxRequest.Merge(m, src)
+}
+func (m *QueryMalleatedTxRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryMalleatedTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryMalleatedTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryMalleatedTxRequest proto.InternalMessageInfo
+
+func (m *QueryMalleatedTxRequest) GetParentHash() string {
+	if m != nil {
+		return m.ParentHash
+	}
+	return ""
+}
+
+// QueryMalleatedTxResponse is the response type for the Query/MalleatedTx RPC
+// method.
+type QueryMalleatedTxResponse struct {
+	MalleatedTx MalleatedTx `protobuf:"bytes,1,opt,name=malleated_tx,json=malleatedTx,proto3" json:"malleated_tx"`
+}
+
+func (m *QueryMalleatedTxResponse) Reset()         { *m = QueryMalleatedTxResponse{} }
+func (m *QueryMalleatedTxResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryMalleatedTxResponse) ProtoMessage()    {}
+func (*QueryMalleatedTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d907c42280cbd58, []int{11}
+}
+func (m *QueryMalleatedTxResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryMalleatedTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryMalleatedTxResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryMalleatedTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryMalleatedTxResponse.Merge(m, src)
+}
+func (m *QueryMalleatedTxResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryMalleatedTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryMalleatedTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryMalleatedTxResponse proto.InternalMessageInfo
+
+func (m *QueryMalleatedTxResponse) GetMalleatedTx() MalleatedTx {
+	if m != nil {
+		return m.MalleatedTx
+	}
+	return MalleatedTx{}
+}
+
+func init() {
+	proto.RegisterType((*QueryParamsRequest)(nil), "payment.QueryParamsRequest")
+	proto.RegisterType((*QueryParamsResponse)(nil), "payment.QueryParamsResponse")
+	proto.RegisterType((*QueryNamespaceStatsRequest)(nil), "payment.QueryNamespaceStatsRequest")
+	proto.RegisterType((*QueryNamespaceStatsResponse)(nil), "payment.QueryNamespaceStatsResponse")
+	proto.RegisterType((*QuerySignerStatsRequest)(nil), "payment.QuerySignerStatsRequest")
+	proto.RegisterType((*QuerySignerStatsResponse)(nil), "payment.QuerySignerStatsResponse")
+	proto.RegisterType((*QueryCommitmentRequest)(nil), "payment.QueryCommitmentRequest")
+	proto.RegisterType((*QueryCommitmentResponse)(nil), "payment.QueryCommitmentResponse")
+	proto.RegisterType((*QueryRejectionRequest)(nil), "payment.QueryRejectionRequest")
+	proto.RegisterType((*QueryRejectionResponse)(nil), "payment.QueryRejectionResponse")
+	proto.RegisterType((*QueryMalleatedTxRequest)(nil), "payment.QueryMalleatedTxRequest")
+	proto.RegisterType((*QueryMalleatedTxResponse)(nil), "payment.QueryMalleatedTxResponse")
+}
+
 func init() { proto.RegisterFile("payment/query.proto", fileDescriptor_0d907c42280cbd58) }
 
 var fileDescriptor_0d907c42280cbd58 = []byte{
-	// 187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x3c, 0xce, 0xb1, 0x8e, 0x82, 0x40,
-	0x10, 0x06, 0x60, 0x28, 0xee, 0x48, 0x28, 0xef, 0x3a, 0x72, 0xd9, 0x07, 0xb8, 0x44, 0x26, 0xc8,
-	0x1b, 0xd8, 0x5b, 0xd8, 0xda, 0xcd, 0x92, 0xc9, 0xba, 0x09, 0xec, 0xac, 0xec, 0x60, 0xe4, 0x2d,
-	0x7c, 0x2c, 0x4b, 0x4a, 0x4b, 0x03, 0x2f, 0x62, 0x04, 0xb5, 0x9b, 0x4c, 0xbe, 0xff, 0xcf, 0x9f,
-	0xfe, 0x7a, 0xec, 0x1b, 0x72, 0x02, 0xc7, 0x8e, 0xda, 0x3e, 0xf7, 0x2d, 0x0b, 0xff, 0x24, 0xaf,
-	0x67, 0xf6, 0x67, 0x98, 0x4d, 0x4d, 0x80, 0xde, 0x02, 0x3a, 0xc7, 0x82, 0x62, 0xd9, 0x85, 0x85,
-	0x65, 0xff, 0x15, 0x87, 0x86, 0x03, 0x68, 0x0c, 0xb4, 0xe4, 0xe1, 0x54, 0x68, 0x12, 0x2c, 0xc0,
-	0xa3, 0xb1, 0x6e, 0xc6, 0x8b, 0x5d, 0x27, 0xe9, 0xd7, 0xee, 0x29, 0x36, 0xdb, 0xeb, 0xa8, 0xe2,
-	0x61, 0x54, 0xf1, 0x7d, 0x54, 0xf1, 0x65, 0x52, 0xd1, 0x30, 0xa9, 0xe8, 0x36, 0xa9, 0x68, 0x5f,
-	0x1a, 0x2b, 0x87, 0x4e, 0xe7, 0x15, 0x37, 0x50, 0x51, 0x4d, 0x41, 0x2c, 0x72, 0x6b, 0x3e, 0xf7,
-	0x0a, 0xbd, 0x87, 0x33, 0xbc, 0x07, 0x4b, 0xef, 0x29, 0xe8, 0xef, 0xb9, 0xbe, 0x7c, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x99, 0xff, 0xee, 0xed, 0xc8, 0x00, 0x00, 0x00,
+	// 771 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4f, 0x4f, 0x13, 0x4f,
+	0x18, 0xee, 0xf2, 0x83, 0x12, 0xde, 0x6d, 0x7e, 0x98, 0x01, 0x69, 0xb3, 0x90, 0x6d, 0x59, 0x89,
+	0xa9, 0x98, 0x76, 0x81, 0xc6, 0x8b, 0x89, 0x31, 0x41, 0x0f, 0x6a, 0xc4, 0xe8, 0xe2, 0x45, 0x2f,
+	0xcd, 0xb4, 0x4c, 0xb6, 0x6b, 0xba, 0x7f, 0xd8, 0x99, 0x92, 0x42, 0xad, 0x26, 0xdc, 0xbc, 0x91,
+	0xf8, 0xa5, 0x38, 0x92, 0x78, 0xf1, 0x64, 0x0c, 0xf8, 0x41, 0xcc, 0xce, 0xce, 0x4e, 0x77, 0xbb,
+	0x40, 0xbc, 0xcd, 0xbe, 0xf3, 0xbc, 0xcf, 0xf3, 0xcc, 0x9b, 0xf7, 0x69, 0x61, 0x29, 0xc0, 0xc7,
+	0x2e, 0xf1, 0x98, 0x79, 0x38, 0x20, 0xe1, 0x71, 0x33, 0x08, 0x7d, 0xe6, 0xa3, 0x79, 0x51, 0xd4,
+	0xd6, 0x6c, 0xdf, 0xb7, 0xfb, 0xc4, 0xc4, 0x81, 0x63, 0x62, 0xcf, 0xf3, 0x19, 0x66, 0x8e, 0xef,
+	0xd1, 0x18, 0xa6, 0x6d, 0x76, 0x7d, 0xea, 0xfa, 0xd4, 0xec, 0x60, 0x4a, 0xe2, 0x7e, 0xf3, 0x68,
+	0xbb, 0x43, 0x18, 0xde, 0x36, 0x03, 0x6c, 0x3b, 0x1e, 0x07, 0x0b, 0xec, 0xb2, 0xed, 0xdb, 0x3e,
+	0x3f, 0x9a, 0xd1, 0x49, 0x54, 0xcb, 0x89, 0xba, 0x8b, 0xfb, 0x7d, 0x82, 0x19, 0x39, 0x48, 0xe0,
+	0xc9, 0x45, 0x80, 0x43, 0xec, 0xd2, 0x69, 0x78, 0x48, 0x3e, 0x91, 0x6e, 0x8a, 0x5d, 0xbe, 0x82,
+	0x32, 0xcc, 0x04, 0xda, 0x58, 0x06, 0xf4, 0x2e, 0x32, 0xf5, 0x96, 0x53, 0x58, 0xe4, 0x70, 0x40,
+	0x28, 0x33, 0x9e, 0xc3, 0x52, 0xa6, 0x4a, 0x03, 0xdf, 0xa3, 0x04, 0x35, 0xa0, 0x18, 0x4b, 0x55,
+	0x94, 0x9a, 0x52, 0x57, 0x77, 0x16, 0x9b, 0x82, 0xb2, 0x19, 0x03, 0x77, 0x67, 0xcf, 0x7f, 0x55,
+	0x0b, 0x96, 0x00, 0x19, 0x4f, 0x41, 0xe3, 0x2c, 0x6f, 0xb0, 0x4b, 0x68, 0x80, 0xbb, 0x64, 0x3f,
+	0x12, 0x16, 0x1a, 0x68, 0x1d, 0x4a, 0x5e, 0x72, 0xd1, 0x76, 0x0e, 0x38, 0xe5, 0x82, 0xa5, 0xca,
+	0xda, 0xcb, 0x03, 0xc3, 0x82, 0xd5, 0x6b, 0x09, 0x84, 0x9d, 0x16, 0xcc, 0xf1, 0xa7, 0x08, 0x37,
+	0x65, 0xe9, 0x26, 0x8b, 0x17, 0xae, 0x62, 0xac, 0xb1, 0x0d, 0x65, 0xce, 0xb9, 0xef, 0xd8, 0x1e,
+	0x09, 0x33, 0x8e, 0x56, 0xa0, 0x48, 0x79, 0x55, 0x78, 0x11, 0x5f, 0xc6, 0x6b, 0xa8, 0xe4, 0x5b,
+	0x84, 0x87, 0xad, 0xac, 0x87, 0x65, 0xe9, 0x21, 0x05, 0xce, 0x1a, 0x38, 0x82, 0x15, 0xce, 0xf6,
+	0xcc, 0x77, 0x5d, 0x87, 0x45, 0xd8, 0x7f, 0x9f, 0x08, 0xaa, 0xc0, 0xbc, 0x4b, 0x28, 0xc5, 0x36,
+	0xa9, 0xcc, 0xd4, 0x94, 0x7a, 0xc9, 0x4a, 0x3e, 0x51, 0x15, 0x54, 0x7a, 0x38, 0xc0, 0x21, 0x69,
+	0x53, 0xe7, 0x84, 0x54, 0xfe, 0xab, 0x29, 0xf5, 0x59, 0x0b, 0xe2, 0xd2, 0xbe, 0x73, 0x42, 0x8c,
+	0xaf, 0x50, 0xce, 0xe9, 0x8a, 0x47, 0x3c, 0x80, 0x3b, 0xb4, 0x17, 0xb5, 0x76, 0xe5, 0x1d, 0x17,
+	0x2f, 0x59, 0x8b, 0xbc, 0x3e, 0x69, 0x89, 0x3c, 0x0a, 0xc5, 0x58, 0x67, 0x86, 0xeb, 0xa8, 0xa2,
+	0x16, 0x09, 0xf1, 0x31, 0x46, 0x5d, 0x54, 0x98, 0x10, 0x5f, 0xc6, 0x16, 0xdc, 0xe5, 0x06, 0xac,
+	0x64, 0x2f, 0x93, 0x77, 0x97, 0x61, 0x9e, 0x0d, 0xdb, 0x3d, 0x4c, 0x7b, 0xc9, 0xe0, 0xd9, 0xf0,
+	0x05, 0xa6, 0x3d, 0xe3, 0x15, 0xac, 0x4c, 0x77, 0xc8, 0xb1, 0x2f, 0xc8, 0xf5, 0x16, 0xa3, 0x47,
+	0x72, 0xf4, 0x13, 0xf8, 0x04, 0x64, 0x3c, 0x16, 0xcf, 0xdf, 0x4b, 0x42, 0xf4, 0x7e, 0x98, 0xe8,
+	0x57, 0x41, 0x0d, 0x70, 0x48, 0x3c, 0x96, 0xf6, 0x00, 0x71, 0x89, 0xfb, 0xf8, 0x00, 0x95, 0x7c,
+	0xaf, 0x70, 0xf2, 0x04, 0x4a, 0x32, 0x97, 0x6d, 0x36, 0xcc, 0xed, 0x41, 0xaa, 0x47, 0xec, 0x81,
+	0xea, 0x4e, 0x4a, 0x3b, 0xa7, 0x45, 0x98, 0xe3, 0xdc, 0x88, 0x40, 0x31, 0x4e, 0x11, 0x5a, 0x95,
+	0xcd, 0xf9, 0x68, 0x6a, 0x6b, 0xd7, 0x5f, 0xc6, 0x6e, 0x8c, 0xda, 0xe9, 0x8f, 0x3f, 0xdf, 0x67,
+	0x34, 0x54, 0x31, 0xbb, 0xa4, 0x4f, 0x28, 0x73, 0xb0, 0x99, 0xfd, 0x91, 0x40, 0x67, 0x0a, 0xfc,
+	0x9f, 0xcd, 0x07, 0xba, 0x97, 0xa5, 0xbc, 0x36, 0xae, 0xda, 0xc6, 0xed, 0x20, 0xa1, 0xdf, 0xe2,
+	0xfa, 0x0d, 0xf4, 0x30, 0xaf, 0x2f, 0xd7, 0x98, 0x9a, 0xa3, 0xf4, 0x9a, 0x8f, 0xd1, 0x17, 0x50,
+	0x53, 0x69, 0x41, 0xb5, 0xac, 0x52, 0x3e, 0xa8, 0xda, 0xfa, 0x2d, 0x08, 0x61, 0x64, 0x93, 0x1b,
+	0xd9, 0x40, 0x46, 0xde, 0x48, 0x9c, 0x6a, 0x6a, 0x8e, 0xe2, 0xc3, 0x18, 0x0d, 0x00, 0x52, 0x1b,
+	0x5e, 0xcd, 0x92, 0xe7, 0x62, 0xaa, 0xd5, 0x6e, 0x06, 0x08, 0xf1, 0x0d, 0x2e, 0xae, 0xa3, 0xb5,
+	0xbc, 0xf8, 0x24, 0x61, 0xe8, 0x33, 0x2c, 0xc8, 0x4d, 0x45, 0x7a, 0x96, 0x74, 0x3a, 0x23, 0x5a,
+	0xf5, 0xc6, 0x7b, 0xa1, 0xd9, 0xe4, 0x9a, 0x75, 0x74, 0x3f, 0xaf, 0x29, 0x43, 0x40, 0xcd, 0x91,
+	0x08, 0xda, 0x18, 0x7d, 0x53, 0x40, 0x4d, 0xed, 0xe6, 0xf4, 0xd4, 0xf3, 0x31, 0xd1, 0xd6, 0x6f,
+	0x41, 0x08, 0x13, 0x8f, 0xb8, 0x09, 0x13, 0x35, 0xf2, 0x26, 0xd2, 0x21, 0xa1, 0xe6, 0x28, 0x15,
+	0xb8, 0xf1, 0xee, 0xde, 0xf9, 0xa5, 0xae, 0x5c, 0x5c, 0xea, 0xca, 0xef, 0x4b, 0x5d, 0x39, 0xbb,
+	0xd2, 0x0b, 0x17, 0x57, 0x7a, 0xe1, 0xe7, 0x95, 0x5e, 0xf8, 0xd8, 0xb2, 0x1d, 0xd6, 0x1b, 0x74,
+	0x9a, 0x5d, 0xdf, 0x95, 0x94, 0x7e, 0x68, 0xcb, 0x73, 0x03, 0x07, 0x81, 0x39, 0x94, 0x22, 0xec,
+	0x38, 0x20, 0xb4, 0x53, 0xe4, 0x7f, 0x6d, 0xad, 0xbf, 0x03, 0x00, 0x36, 0xba, 0xa9, 0x62, 0xb7,
+	0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -55,6 +694,22 @@ const _ = grpc.SupportPackageIsVersion4
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type QueryClient interface {
+	// Params queries the parameters of the payment module.
+	Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error)
+	// NamespaceStats queries the totals of the messages paid for in a namespace.
+	NamespaceStats(ctx context.Context, in *QueryNamespaceStatsRequest, opts ...grpc.CallOption) (*QueryNamespaceStatsResponse, error)
+	// SignerStats queries the totals of the messages paid for by a signer.
+	SignerStats(ctx context.Context, in *QuerySignerStatsRequest, opts ...grpc.CallOption) (*QuerySignerStatsResponse, error)
+	// Commitment calculates the share commitment of a message for a square
+	// size, which is the commitment that a MsgWirePayForMessage must include.
+	Commitment(ctx context.Context, in *QueryCommitmentRequest, opts ...grpc.CallOption) (*QueryCommitmentResponse, error)
+	// Rejection queries why a tx was recently left out of a block proposed by
+	// this node.
+	Rejection(ctx context.Context, in *QueryRejectionRequest, opts ...grpc.CallOption) (*QueryRejectionResponse, error)
+	// MalleatedTx queries the malleated tx that was included in a block in
+	// place of a tx containing MsgWirePayForMessages, using the hash of the
+	// original tx.
+	MalleatedTx(ctx context.Context, in *QueryMalleatedTxRequest, opts ...grpc.CallOption) (*QueryMalleatedTxResponse, error)
 }
 
 type queryClient struct {
@@ -65,22 +720,1948 @@ func NewQueryClient(cc grpc1.ClientConn) QueryClient {
 	return &queryClient{cc}
 }
 
+func (c *queryClient) Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error) {
+	out := new(QueryParamsResponse)
+	err := c.cc.Invoke(ctx, "/payment.Query/Params", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) NamespaceStats(ctx context.Context, in *QueryNamespaceStatsRequest, opts ...grpc.CallOption) (*QueryNamespaceStatsResponse, error) {
+	out := new(QueryNamespaceStatsResponse)
+	err := c.cc.Invoke(ctx, "/payment.Query/NamespaceStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) SignerStats(ctx context.Context, in *QuerySignerStatsRequest, opts ...grpc.CallOption) (*QuerySignerStatsResponse, error) {
+	out := new(QuerySignerStatsResponse)
+	err := c.cc.Invoke(ctx, "/payment.Query/SignerStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Commitment(ctx context.Context, in *QueryCommitmentRequest, opts ...grpc.CallOption) (*QueryCommitmentResponse, error) {
+	out := new(QueryCommitmentResponse)
+	err := c.cc.Invoke(ctx, "/payment.Query/Commitment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Rejection(ctx context.Context, in *QueryRejectionRequest, opts ...grpc.CallOption) (*QueryRejectionResponse, error) {
+	out := new(QueryRejectionResponse)
+	err := c.cc.Invoke(ctx, "/payment.Query/Rejection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) MalleatedTx(ctx context.Context, in *QueryMalleatedTxRequest, opts ...grpc.CallOption) (*QueryMalleatedTxResponse, error) {
+	out := new(QueryMalleatedTxResponse)
+	err := c.cc.Invoke(ctx, "/payment.Query/MalleatedTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
+	// Params queries the parameters of the payment module.
+	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
+	// NamespaceStats queries the totals of the messages paid for in a namespace.
+	NamespaceStats(context.Context, *QueryNamespaceStatsRequest) (*QueryNamespaceStatsResponse, error)
+	// SignerStats queries the totals of the messages paid for by a signer.
+	SignerStats(context.Context, *QuerySignerStatsRequest) (*QuerySignerStatsResponse, error)
+	// Commitment calculates the share commitment of a message for a square
+	// size, which is the commitment that a MsgWirePayForMessage must include.
+	Commitment(context.Context, *QueryCommitmentRequest) (*QueryCommitmentResponse, error)
+	// Rejection queries why a tx was recently left out of a block proposed by
+	// this node.
+	Rejection(context.Context, *QueryRejectionRequest) (*QueryRejectionResponse, error)
+	// MalleatedTx queries the malleated tx that was included in a block in
+	// place of a tx containing MsgWirePayForMessages, using the hash of the
+	// original tx.
+	MalleatedTx(context.Context, *QueryMalleatedTxRequest) (*QueryMalleatedTxResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
 type UnimplementedQueryServer struct {
 }
 
+func (*UnimplementedQueryServer) Params(ctx context.Context, req *QueryParamsRequest) (*QueryParamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Params not implemented")
+}
+func (*UnimplementedQueryServer) NamespaceStats(ctx context.Context, req *QueryNamespaceStatsRequest) (*QueryNamespaceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NamespaceStats not implemented")
+}
+func (*UnimplementedQueryServer) SignerStats(ctx context.Context, req *QuerySignerStatsRequest) (*QuerySignerStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignerStats not implemented")
+}
+func (*UnimplementedQueryServer) Commitment(ctx context.Context, req *QueryCommitmentRequest) (*QueryCommitmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Commitment not implemented")
+}
+func (*UnimplementedQueryServer) Rejection(ctx context.Context, req *QueryRejectionRequest) (*QueryRejectionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rejection not implemented")
+}
+func (*UnimplementedQueryServer) MalleatedTx(ctx context.Context, req *QueryMalleatedTxRequest) (*QueryMalleatedTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MalleatedTx not implemented")
+}
+
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
 }
 
+func _Query_Params_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Params(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.Query/Params",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Params(ctx, req.(*QueryParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_NamespaceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryNamespaceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).NamespaceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.Query/NamespaceStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).NamespaceStats(ctx, req.(*QueryNamespaceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_SignerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySignerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).SignerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.Query/SignerStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).SignerStats(ctx, req.(*QuerySignerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Commitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryCommitmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Commitment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.Query/Commitment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Commitment(ctx, req.(*QueryCommitmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Rejection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRejectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Rejection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.Query/Rejection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Rejection(ctx, req.(*QueryRejectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_MalleatedTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMalleatedTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).MalleatedTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/payment.Query/MalleatedTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).MalleatedTx(ctx, req.(*QueryMalleatedTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "payment.Query",
 	HandlerType: (*QueryServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams:     []grpc.StreamDesc{},
-	Metadata:    "payment/query.proto",
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Params",
+			Handler:    _Query_Params_Handler,
+		},
+		{
+			MethodName: "NamespaceStats",
+			Handler:    _Query_NamespaceStats_Handler,
+		},
+		{
+			MethodName: "SignerStats",
+			Handler:    _Query_SignerStats_Handler,
+		},
+		{
+			MethodName: "Commitment",
+			Handler:    _Query_Commitment_Handler,
+		},
+		{
+			MethodName: "Rejection",
+			Handler:    _Query_Rejection_Handler,
+		},
+		{
+			MethodName: "MalleatedTx",
+			Handler:    _Query_MalleatedTx_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "payment/query.proto",
+}
+
+func (m *QueryParamsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryParamsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryParamsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryParamsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryParamsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryParamsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Params.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryNamespaceStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryNamespaceStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryNamespaceStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NamespaceId) > 0 {
+		i -= len(m.NamespaceId)
+		copy(dAtA[i:], m.NamespaceId)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.NamespaceId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryNamespaceStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryNamespaceStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryNamespaceStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QuerySignerStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuerySignerStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuerySignerStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signer) > 0 {
+		i -= len(m.Signer)
+		copy(dAtA[i:], m.Signer)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Signer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuerySignerStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuerySignerStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuerySignerStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryCommitmentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryCommitmentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryCommitmentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SquareSize != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.SquareSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.NamespaceId) > 0 {
+		i -= len(m.NamespaceId)
+		copy(dAtA[i:], m.NamespaceId)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.NamespaceId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryCommitmentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryCommitmentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryCommitmentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Shares != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Shares))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MessageSize != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.MessageSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ShareCommitment) > 0 {
+		i -= len(m.ShareCommitment)
+		copy(dAtA[i:], m.ShareCommitment)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.ShareCommitment)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRejectionRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
+
+func (m *QueryRejectionRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRejectionRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRejectionResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRejectionResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRejectionResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Rejection != nil {
+		{
+			size, err := m.Rejection.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryMalleatedTxRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryMalleatedTxRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryMalleatedTxRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ParentHash) > 0 {
+		i -= len(m.ParentHash)
+		copy(dAtA[i:], m.ParentHash)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.ParentHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryMalleatedTxResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryMalleatedTxResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryMalleatedTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.MalleatedTx.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryParamsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *QueryParamsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QueryNamespaceStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NamespaceId)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryNamespaceStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Stats.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QuerySignerStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signer)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QuerySignerStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Stats.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QueryCommitmentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NamespaceId)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.SquareSize != 0 {
+		n += 1 + sovQuery(uint64(m.SquareSize))
+	}
+	return n
+}
+
+func (m *QueryCommitmentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ShareCommitment)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.MessageSize != 0 {
+		n += 1 + sovQuery(uint64(m.MessageSize))
+	}
+	if m.Shares != 0 {
+		n += 1 + sovQuery(uint64(m.Shares))
+	}
+	return n
+}
+
+func (m *QueryRejectionRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRejectionResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Rejection != nil {
+		l = m.Rejection.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryMalleatedTxRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ParentHash)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryMalleatedTxResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MalleatedTx.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *QueryParamsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryParamsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryParamsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryParamsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryParamsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryNamespaceStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryNamespaceStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryNamespaceStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryNamespaceStatsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryNamespaceStatsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryNamespaceStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuerySignerStatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuerySignerStatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuerySignerStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuerySignerStatsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuerySignerStatsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuerySignerStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryCommitmentRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryCommitmentRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryCommitmentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = append(m.Message[:0], dAtA[iNdEx:postIndex]...)
+			if m.Message == nil {
+				m.Message = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SquareSize", wireType)
+			}
+			m.SquareSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SquareSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryCommitmentResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryCommitmentResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryCommitmentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShareCommitment", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ShareCommitment = append(m.ShareCommitment[:0], dAtA[iNdEx:postIndex]...)
+			if m.ShareCommitment == nil {
+				m.ShareCommitment = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageSize", wireType)
+			}
+			m.MessageSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessageSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shares", wireType)
+			}
+			m.Shares = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Shares |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRejectionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRejectionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRejectionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryRejectionResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryRejectionResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryRejectionResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rejection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rejection == nil {
+				m.Rejection = &Rejection{}
+			}
+			if err := m.Rejection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryMalleatedTxRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryMalleatedTxRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryMalleatedTxRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ParentHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ParentHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryMalleatedTxResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryMalleatedTxResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryMalleatedTxResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MalleatedTx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MalleatedTx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipQuery(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthQuery
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupQuery
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthQuery
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthQuery        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowQuery          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupQuery = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,516 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: payment/rejection.proto
+
+package types
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RejectionReason describes why a block producer did not include a tx in the
+// block it proposed.
+type RejectionReason int32
+
+const (
+	// REJECTION_REASON_UNSPECIFIED is the default value and is never used.
+	RejectionReason_REJECTION_REASON_UNSPECIFIED RejectionReason = 0
+	// REJECTION_REASON_DECODE_FAILURE is used for txs that could not be decoded
+	// into an sdk.Tx.
+	RejectionReason_REJECTION_REASON_DECODE_FAILURE RejectionReason = 1
+	// REJECTION_REASON_MULTIPLE_MSGS is used for txs that contain a
+	// MsgWirePayForMessage along with other sdk.Msgs.
+	RejectionReason_REJECTION_REASON_MULTIPLE_MSGS RejectionReason = 2
+	// REJECTION_REASON_INVALID_BASIC is used for txs that failed ValidateBasic.
+	RejectionReason_REJECTION_REASON_INVALID_BASIC RejectionReason = 3
+	// REJECTION_REASON_MISSING_COMMITMENT is used for MsgWirePayForMessages that
+	// don't include a share commitment for the square size of the block.
+	RejectionReason_REJECTION_REASON_MISSING_COMMITMENT RejectionReason = 4
+	// REJECTION_REASON_MALLEATION_FAILURE is used for MsgWirePayForMessages that
+	// could not be malleated into a MsgPayForMessage.
+	RejectionReason_REJECTION_REASON_MALLEATION_FAILURE RejectionReason = 5
+	// REJECTION_REASON_PARAMS_VIOLATION is used for MsgWirePayForMessages that
+	// don't respect the payment module's params, such as the max message bytes
+	// or the required square sizes.
+	RejectionReason_REJECTION_REASON_PARAMS_VIOLATION RejectionReason = 6
+	// REJECTION_REASON_UNWRAPPED_PFM is used for txs that contain a
+	// MsgPayForMessage without being a malleated tx.
+	RejectionReason_REJECTION_REASON_UNWRAPPED_PFM RejectionReason = 7
+)
+
+var RejectionReason_name = map[int32]string{
+	0: "REJECTION_REASON_UNSPECIFIED",
+	1: "REJECTION_REASON_DECODE_FAILURE",
+	2: "REJECTION_REASON_MULTIPLE_MSGS",
+	3: "REJECTION_REASON_INVALID_BASIC",
+	4: "REJECTION_REASON_MISSING_COMMITMENT",
+	5: "REJECTION_REASON_MALLEATION_FAILURE",
+	6: "REJECTION_REASON_PARAMS_VIOLATION",
+	7: "REJECTION_REASON_UNWRAPPED_PFM",
+}
+
+var RejectionReason_value = map[string]int32{
+	"REJECTION_REASON_UNSPECIFIED":        0,
+	"REJECTION_REASON_DECODE_FAILURE":     1,
+	"REJECTION_REASON_MULTIPLE_MSGS":      2,
+	"REJECTION_REASON_INVALID_BASIC":      3,
+	"REJECTION_REASON_MISSING_COMMITMENT": 4,
+	"REJECTION_REASON_MALLEATION_FAILURE": 5,
+	"REJECTION_REASON_PARAMS_VIOLATION":   6,
+	"REJECTION_REASON_UNWRAPPED_PFM":      7,
+}
+
+func (x RejectionReason) String() string {
+	return proto.EnumName(RejectionReason_name, int32(x))
+}
+
+func (RejectionReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_527d88c86ba393e5, []int{0}
+}
+
+// Rejection records that a tx was not included in a block proposed by this
+// node.
+type Rejection struct {
+	// tx_hash is the hex encoded hash of the rejected tx.
+	TxHash string          `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	Reason RejectionReason `protobuf:"varint,2,opt,name=reason,proto3,enum=payment.RejectionReason" json:"reason,omitempty"`
+	// error describes the error that caused the rejection.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// height is the height of the block that the tx was rejected from.
+	Height int64 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *Rejection) Reset()         { *m = Rejection{} }
+func (m *Rejection) String() string { return proto.CompactTextString(m) }
+func (*Rejection) ProtoMessage()    {}
+func (*Rejection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_527d88c86ba393e5, []int{0}
+}
+func (m *Rejection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Rejection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Rejection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Rejection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Rejection.Merge(m, src)
+}
+func (m *Rejection) XXX_Size() int {
+	return m.Size()
+}
+func (m *Rejection) XXX_DiscardUnknown() {
+	xxx_messageInfo_Rejection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Rejection proto.InternalMessageInfo
+
+func (m *Rejection) GetTxHash() string {
+	if m != nil {
+		return m.TxHash
+	}
+	return ""
+}
+
+func (m *Rejection) GetReason() RejectionReason {
+	if m != nil {
+		return m.Reason
+	}
+	return RejectionReason_REJECTION_REASON_UNSPECIFIED
+}
+
+func (m *Rejection) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *Rejection) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("payment.RejectionReason", RejectionReason_name, RejectionReason_value)
+	proto.RegisterType((*Rejection)(nil), "payment.Rejection")
+}
+
+func init() { proto.RegisterFile("payment/rejection.proto", fileDescriptor_527d88c86ba393e5) }
+
+var fileDescriptor_527d88c86ba393e5 = []byte{
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xc1, 0x6e, 0xd3, 0x30,
+	0x18, 0xc7, 0xe3, 0x76, 0x4b, 0x35, 0x1f, 0x20, 0xb2, 0x10, 0xcb, 0x01, 0x85, 0xb0, 0x09, 0x51,
+	0x21, 0x91, 0x20, 0xf6, 0x04, 0x5e, 0xe2, 0x0e, 0xa3, 0x38, 0x89, 0xec, 0x64, 0x48, 0x5c, 0xac,
+	0xac, 0xb2, 0x9a, 0x20, 0xd6, 0x44, 0x89, 0x91, 0xba, 0x3b, 0x0f, 0xc0, 0x0b, 0x71, 0xe7, 0xb8,
+	0x23, 0x47, 0xd4, 0xbe, 0x08, 0x52, 0x48, 0x77, 0x58, 0x7a, 0xf3, 0xe7, 0xff, 0xef, 0xfb, 0xe9,
+	0x3b, 0xfc, 0xe1, 0x69, 0x53, 0xdc, 0xdd, 0xaa, 0xb5, 0xf6, 0x5b, 0xf5, 0x55, 0x2d, 0x75, 0x55,
+	0xaf, 0xbd, 0xa6, 0xad, 0x75, 0x8d, 0x66, 0x43, 0x70, 0xf6, 0x03, 0xc0, 0x13, 0xbe, 0x0f, 0xd1,
+	0x29, 0x9c, 0xe9, 0x8d, 0x2c, 0x8b, 0xae, 0xb4, 0x81, 0x0b, 0xe6, 0x27, 0xdc, 0xd4, 0x9b, 0x8f,
+	0x45, 0x57, 0xa2, 0xf7, 0xd0, 0x6c, 0x55, 0xd1, 0xd5, 0x6b, 0x7b, 0xe2, 0x82, 0xf9, 0x93, 0x0f,
+	0xb6, 0x37, 0x08, 0xbc, 0x87, 0x65, 0xde, 0xe7, 0x7c, 0xe0, 0xd0, 0x33, 0x78, 0xac, 0xda, 0xb6,
+	0x6e, 0xed, 0x69, 0x2f, 0xfa, 0x3f, 0xa0, 0xe7, 0xd0, 0x2c, 0x55, 0xb5, 0x2a, 0xb5, 0x7d, 0xe4,
+	0x82, 0xf9, 0x94, 0x0f, 0xd3, 0xdb, 0x5f, 0x13, 0xf8, 0xf4, 0x91, 0x09, 0xb9, 0xf0, 0x05, 0x27,
+	0x9f, 0x48, 0x90, 0xd1, 0x24, 0x96, 0x9c, 0x60, 0x91, 0xc4, 0x32, 0x8f, 0x45, 0x4a, 0x02, 0xba,
+	0xa0, 0x24, 0xb4, 0x0c, 0x74, 0x0e, 0x5f, 0x8e, 0x88, 0x90, 0x04, 0x49, 0x48, 0xe4, 0x02, 0xd3,
+	0x28, 0xe7, 0xc4, 0x02, 0xe8, 0x0c, 0x3a, 0x23, 0x88, 0xe5, 0x51, 0x46, 0xd3, 0x88, 0x48, 0x26,
+	0xae, 0x84, 0x35, 0x39, 0xc8, 0xd0, 0xf8, 0x1a, 0x47, 0x34, 0x94, 0x97, 0x58, 0xd0, 0xc0, 0x9a,
+	0xa2, 0x37, 0xf0, 0x7c, 0xec, 0xa1, 0x42, 0xd0, 0xf8, 0x4a, 0x06, 0x09, 0x63, 0x34, 0x63, 0x24,
+	0xce, 0xac, 0xa3, 0xc3, 0x20, 0x8e, 0x22, 0x82, 0xfb, 0x9f, 0xfd, 0x65, 0xc7, 0xe8, 0x35, 0x7c,
+	0x35, 0x02, 0x53, 0xcc, 0x31, 0x13, 0xf2, 0x9a, 0x26, 0x51, 0x8f, 0x5b, 0xe6, 0xc1, 0xe3, 0xf2,
+	0xf8, 0x33, 0xc7, 0x69, 0x4a, 0x42, 0x99, 0x2e, 0x98, 0x35, 0xbb, 0x64, 0xbf, 0xb7, 0x0e, 0xb8,
+	0xdf, 0x3a, 0xe0, 0xef, 0xd6, 0x01, 0x3f, 0x77, 0x8e, 0x71, 0xbf, 0x73, 0x8c, 0x3f, 0x3b, 0xc7,
+	0xf8, 0x72, 0xb1, 0xaa, 0x74, 0xf9, 0xfd, 0xc6, 0x5b, 0xd6, 0xb7, 0xfe, 0x52, 0x7d, 0x53, 0x9d,
+	0xae, 0x8a, 0xba, 0x5d, 0x3d, 0xbc, 0xdf, 0x15, 0x4d, 0xe3, 0x6f, 0xfc, 0x7d, 0x51, 0xf4, 0x5d,
+	0xa3, 0xba, 0x1b, 0xb3, 0x6f, 0xc9, 0xc5, 0xbf, 0x01, 0x00, 0x5f, 0x53, 0xae, 0x0a, 0x40, 0x02,
+	0x00, 0x00,
+}
+
+func (m *Rejection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Rejection) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Rejection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintRejection(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintRejection(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Reason != 0 {
+		i = encodeVarintRejection(dAtA, i, uint64(m.Reason))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintRejection(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRejection(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRejection(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Rejection) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovRejection(uint64(l))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovRejection(uint64(m.Reason))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovRejection(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovRejection(uint64(m.Height))
+	}
+	return n
+}
+
+func sovRejection(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRejection(x uint64) (n int) {
+	return sovRejection(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Rejection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRejection
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Rejection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Rejection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRejection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRejection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRejection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRejection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= RejectionReason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRejection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRejection
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRejection
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRejection
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRejection(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRejection
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRejection(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRejection
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRejection
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRejection
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRejection
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupRejection
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthRejection
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthRejection        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRejection          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupRejection = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,786 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: payment/stats.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// NamespaceStats are the running totals of the messages paid for in a
+// namespace.
+type NamespaceStats struct {
+	NamespaceId []byte `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	// total_bytes is the sum of the sizes of the messages paid for.
+	TotalBytes uint64 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// total_messages is the number of messages paid for.
+	TotalMessages uint64 `protobuf:"varint,3,opt,name=total_messages,json=totalMessages,proto3" json:"total_messages,omitempty"`
+	// last_height is the height of the last block that paid for a message in
+	// the namespace.
+	LastHeight int64 `protobuf:"varint,4,opt,name=last_height,json=lastHeight,proto3" json:"last_height,omitempty"`
+}
+
+func (m *NamespaceStats) Reset()         { *m = NamespaceStats{} }
+func (m *NamespaceStats) String() string { return proto.CompactTextString(m) }
+func (*NamespaceStats) ProtoMessage()    {}
+func (*NamespaceStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fbc81781d96c92f2, []int{0}
+}
+func (m *NamespaceStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NamespaceStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NamespaceStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NamespaceStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceStats.Merge(m, src)
+}
+func (m *NamespaceStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *NamespaceStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceStats proto.InternalMessageInfo
+
+func (m *NamespaceStats) GetNamespaceId() []byte {
+	if m != nil {
+		return m.NamespaceId
+	}
+	return nil
+}
+
+func (m *NamespaceStats) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *NamespaceStats) GetTotalMessages() uint64 {
+	if m != nil {
+		return m.TotalMessages
+	}
+	return 0
+}
+
+func (m *NamespaceStats) GetLastHeight() int64 {
+	if m != nil {
+		return m.LastHeight
+	}
+	return 0
+}
+
+// SignerStats are the running totals of the messages paid for by a signer.
+type SignerStats struct {
+	Signer string `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	// total_bytes is the sum of the sizes of the messages paid for.
+	TotalBytes uint64 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	// total_messages is the number of messages paid for.
+	TotalMessages uint64 `protobuf:"varint,3,opt,name=total_messages,json=totalMessages,proto3" json:"total_messages,omitempty"`
+	// total_paid is the sum of the payments for the messages.
+	TotalPaid github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,4,rep,name=total_paid,json=totalPaid,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"total_paid"`
+	// last_height is the height of the last block that the signer paid for a
+	// message in.
+	LastHeight int64 `protobuf:"varint,5,opt,name=last_height,json=lastHeight,proto3" json:"last_height,omitempty"`
+}
+
+func (m *SignerStats) Reset()         { *m = SignerStats{} }
+func (m *SignerStats) String() string { return proto.CompactTextString(m) }
+func (*SignerStats) ProtoMessage()    {}
+func (*SignerStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fbc81781d96c92f2, []int{1}
+}
+func (m *SignerStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignerStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignerStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignerStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignerStats.Merge(m, src)
+}
+func (m *SignerStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignerStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignerStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignerStats proto.InternalMessageInfo
+
+func (m *SignerStats) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+func (m *SignerStats) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *SignerStats) GetTotalMessages() uint64 {
+	if m != nil {
+		return m.TotalMessages
+	}
+	return 0
+}
+
+func (m *SignerStats) GetTotalPaid() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.TotalPaid
+	}
+	return nil
+}
+
+func (m *SignerStats) GetLastHeight() int64 {
+	if m != nil {
+		return m.LastHeight
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*NamespaceStats)(nil), "payment.NamespaceStats")
+	proto.RegisterType((*SignerStats)(nil), "payment.SignerStats")
+}
+
+func init() { proto.RegisterFile("payment/stats.proto", fileDescriptor_fbc81781d96c92f2) }
+
+var fileDescriptor_fbc81781d96c92f2 = []byte{
+	// 363 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0xcf, 0x4e, 0xea, 0x40,
+	0x14, 0xc6, 0x3b, 0x17, 0x2e, 0x37, 0x4c, 0xb9, 0x2c, 0xaa, 0x31, 0x95, 0x45, 0xa9, 0x24, 0x26,
+	0xdd, 0xd0, 0x11, 0x79, 0x03, 0xdc, 0xe8, 0x02, 0x63, 0xca, 0xce, 0x4d, 0x33, 0x6d, 0x27, 0x65,
+	0x94, 0x76, 0x1a, 0xce, 0x68, 0xe4, 0x2d, 0x7c, 0x00, 0x9f, 0xc0, 0x27, 0x61, 0xc9, 0xd2, 0x95,
+	0x1a, 0x78, 0x0b, 0x57, 0xa6, 0x33, 0x85, 0x18, 0xd6, 0xae, 0x7a, 0xce, 0xef, 0xfc, 0xe9, 0x77,
+	0xbe, 0x16, 0x1f, 0x14, 0x74, 0x91, 0xb1, 0x5c, 0x12, 0x90, 0x54, 0x82, 0x5f, 0xcc, 0x85, 0x14,
+	0xd6, 0xbf, 0x0a, 0x76, 0x0e, 0x53, 0x91, 0x0a, 0xc5, 0x48, 0x19, 0xe9, 0x72, 0xc7, 0x89, 0x05,
+	0x64, 0x02, 0x48, 0x44, 0x81, 0x91, 0xc7, 0x41, 0xc4, 0x24, 0x1d, 0x90, 0x58, 0xf0, 0x5c, 0xd7,
+	0x7b, 0x2f, 0x08, 0xb7, 0xaf, 0x69, 0xc6, 0xa0, 0xa0, 0x31, 0x9b, 0x94, 0x7b, 0xad, 0x13, 0xdc,
+	0xca, 0xb7, 0x24, 0xe4, 0x89, 0x8d, 0x5c, 0xe4, 0xb5, 0x02, 0x73, 0xc7, 0xae, 0x12, 0xab, 0x8b,
+	0x4d, 0x29, 0x24, 0x9d, 0x85, 0xd1, 0x42, 0x32, 0xb0, 0xff, 0xb8, 0xc8, 0xab, 0x07, 0x58, 0xa1,
+	0x51, 0x49, 0xac, 0x53, 0xdc, 0xd6, 0x0d, 0x19, 0x03, 0xa0, 0x29, 0x03, 0xbb, 0xa6, 0x7a, 0xfe,
+	0x2b, 0x3a, 0xae, 0x60, 0xb9, 0x67, 0x46, 0x41, 0x86, 0x53, 0xc6, 0xd3, 0xa9, 0xb4, 0xeb, 0x2e,
+	0xf2, 0x6a, 0x01, 0x2e, 0xd1, 0xa5, 0x22, 0xbd, 0x2f, 0x84, 0xcd, 0x09, 0x4f, 0x73, 0x36, 0xd7,
+	0xda, 0x8e, 0x70, 0x03, 0x54, 0xaa, 0x54, 0x35, 0x83, 0x2a, 0xfb, 0x35, 0x41, 0x77, 0x58, 0x0f,
+	0x85, 0x05, 0xe5, 0x89, 0x5d, 0x77, 0x6b, 0x9e, 0x79, 0x7e, 0xec, 0x6b, 0x0f, 0xfd, 0xd2, 0x43,
+	0xbf, 0xf2, 0xd0, 0xbf, 0x10, 0x3c, 0x1f, 0x9d, 0x2d, 0xdf, 0xbb, 0xc6, 0xeb, 0x47, 0xd7, 0x4b,
+	0xb9, 0x9c, 0x3e, 0x44, 0x7e, 0x2c, 0x32, 0x52, 0x19, 0xae, 0x1f, 0x7d, 0x48, 0xee, 0x89, 0x5c,
+	0x14, 0x0c, 0xd4, 0x00, 0x04, 0x4d, 0xb5, 0xfe, 0x86, 0xf2, 0x64, 0xff, 0xf8, 0xbf, 0xfb, 0xc7,
+	0x8f, 0xc6, 0xcb, 0xb5, 0x83, 0x56, 0x6b, 0x07, 0x7d, 0xae, 0x1d, 0xf4, 0xbc, 0x71, 0x8c, 0xd5,
+	0xc6, 0x31, 0xde, 0x36, 0x8e, 0x71, 0x3b, 0xfc, 0xf9, 0x3e, 0x36, 0x63, 0x20, 0x39, 0x15, 0xf3,
+	0x74, 0x17, 0xf7, 0x69, 0x51, 0x90, 0x27, 0xb2, 0xfd, 0x5f, 0x94, 0x80, 0xa8, 0xa1, 0xbe, 0xf8,
+	0xf0, 0x7b, 0x00, 0x75, 0x65, 0x58, 0x96, 0x47, 0x02, 0x00, 0x00,
+}
+
+func (m *NamespaceStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NamespaceStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NamespaceStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastHeight != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.LastHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.TotalMessages != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.TotalMessages))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TotalBytes != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.TotalBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NamespaceId) > 0 {
+		i -= len(m.NamespaceId)
+		copy(dAtA[i:], m.NamespaceId)
+		i = encodeVarintStats(dAtA, i, uint64(len(m.NamespaceId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignerStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignerStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignerStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastHeight != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.LastHeight))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.TotalPaid) > 0 {
+		for iNdEx := len(m.TotalPaid) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TotalPaid[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintStats(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.TotalMessages != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.TotalMessages))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.TotalBytes != 0 {
+		i = encodeVarintStats(dAtA, i, uint64(m.TotalBytes))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Signer) > 0 {
+		i -= len(m.Signer)
+		copy(dAtA[i:], m.Signer)
+		i = encodeVarintStats(dAtA, i, uint64(len(m.Signer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintStats(dAtA []byte, offset int, v uint64) int {
+	offset -= sovStats(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *NamespaceStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NamespaceId)
+	if l > 0 {
+		n += 1 + l + sovStats(uint64(l))
+	}
+	if m.TotalBytes != 0 {
+		n += 1 + sovStats(uint64(m.TotalBytes))
+	}
+	if m.TotalMessages != 0 {
+		n += 1 + sovStats(uint64(m.TotalMessages))
+	}
+	if m.LastHeight != 0 {
+		n += 1 + sovStats(uint64(m.LastHeight))
+	}
+	return n
+}
+
+func (m *SignerStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signer)
+	if l > 0 {
+		n += 1 + l + sovStats(uint64(l))
+	}
+	if m.TotalBytes != 0 {
+		n += 1 + sovStats(uint64(m.TotalBytes))
+	}
+	if m.TotalMessages != 0 {
+		n += 1 + sovStats(uint64(m.TotalMessages))
+	}
+	if len(m.TotalPaid) > 0 {
+		for _, e := range m.TotalPaid {
+			l = e.Size()
+			n += 1 + l + sovStats(uint64(l))
+		}
+	}
+	if m.LastHeight != 0 {
+		n += 1 + sovStats(uint64(m.LastHeight))
+	}
+	return n
+}
+
+func sovStats(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozStats(x uint64) (n int) {
+	return sovStats(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NamespaceStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStats
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceId", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStats
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceId = append(m.NamespaceId[:0], dAtA[iNdEx:postIndex]...)
+			if m.NamespaceId == nil {
+				m.NamespaceId = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalBytes", wireType)
+			}
+			m.TotalBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalMessages", wireType)
+			}
+			m.TotalMessages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalMessages |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHeight", wireType)
+			}
+			m.LastHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStats(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStats
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignerStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStats
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignerStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignerStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthStats
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalBytes", wireType)
+			}
+			m.TotalBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalBytes |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalMessages", wireType)
+			}
+			m.TotalMessages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalMessages |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalPaid", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStats
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStats
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TotalPaid = append(m.TotalPaid, types.Coin{})
+			if err := m.TotalPaid[len(m.TotalPaid)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastHeight", wireType)
+			}
+			m.LastHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStats(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStats
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipStats(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowStats
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStats
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthStats
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupStats
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthStats
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthStats        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowStats          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupStats = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,351 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"google.golang.org/grpc"
+)
+
+// ErrSubmitterPoolClosed is returned when a message is submitted to a
+// SubmitterPool after it was closed
+var ErrSubmitterPoolClosed = errors.New("submitter pool is closed")
+
+// SubmitterPool submits messages concurrently using several accounts. A single
+// account signs its txs one at a time, as each tx uses the next sequence of the
+// account, so the pool submits a message with each of its accounts at the same
+// time, while each account keeps its own sequence. Submitted messages are
+// queued until an account is available, and once the queue is full, Submit
+// blocks until a message is taken from it.
+type SubmitterPool struct {
+	// inFlight is accessed atomically, so it is the first field to be aligned
+	// on 32-bit platforms
+	inFlight int64
+
+	clients []*BlobClient
+	options []TxBuilderOption
+
+	// closeMtx protects closed, and makes sure that no message is queued after
+	// the queue is closed
+	closeMtx sync.RWMutex
+	closed   bool
+	queue    chan *submission
+	wg       sync.WaitGroup
+
+	accounts []*submitterAccount
+}
+
+// submission is a message that is waiting to be submitted by the pool
+type submission struct {
+	ctx       context.Context
+	namespace []byte
+	message   []byte
+	result    chan submissionResult
+}
+
+type submissionResult struct {
+	result *PayForMessageResult
+	err    error
+}
+
+// submitterAccount keeps the metrics of an account of the pool
+type submitterAccount struct {
+	submitted uint64
+	failed    uint64
+	address   string
+}
+
+// NewSubmitterPool creates a SubmitterPool that submits messages using each of
+// the provided signers, which must use different accounts. Up to queueSize
+// messages wait for an account to be available. The options are applied to
+// every tx, and must set a gas limit and fees that are enough for the largest
+// message that is submitted. The pool starts submitting messages right away,
+// and must be closed once it is no longer used.
+func NewSubmitterPool(signers []*KeyringSigner, conn *grpc.ClientConn, queueSize int, options ...TxBuilderOption) (*SubmitterPool, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers for the submitter pool")
+	}
+	if queueSize < 0 {
+		return nil, fmt.Errorf("invalid queue size %d", queueSize)
+	}
+
+	p := &SubmitterPool{
+		clients:  make([]*BlobClient, len(signers)),
+		options:  options,
+		queue:    make(chan *submission, queueSize),
+		accounts: make([]*submitterAccount, len(signers)),
+	}
+	seen := make(map[string]bool, len(signers))
+	for i, signer := range signers {
+		address := signer.GetSignerInfo().GetAddress().String()
+		if seen[address] {
+			return nil, fmt.Errorf("account %s is used by several signers", address)
+		}
+		seen[address] = true
+		p.clients[i] = NewBlobClient(signer, conn)
+		p.accounts[i] = &submitterAccount{address: address}
+	}
+
+	p.wg.Add(len(p.clients))
+	for i := range p.clients {
+		go p.submitMessages(p.clients[i], p.accounts[i])
+	}
+	return p, nil
+}
+
+// SetPollInterval sets how often the accounts of the pool check if their
+// submitted txs were included in a block
+func (p *SubmitterPool) SetPollInterval(interval time.Duration) {
+	for _, client := range p.clients {
+		client.SetPollInterval(interval)
+	}
+}
+
+// Addresses returns the addresses of the accounts of the pool
+func (p *SubmitterPool) Addresses() []sdktypes.AccAddress {
+	addresses := make([]sdktypes.AccAddress, len(p.clients))
+	for i, client := range p.clients {
+		addresses[i] = client.signer.GetSignerInfo().GetAddress()
+	}
+	return addresses
+}
+
+// Submit queues the message to be paid for by the next available account, and
+// waits for the malleated tx to be included in a block, as with
+// BlobClient.SubmitPayForMessage. It blocks while the queue is full, and can
+// be called concurrently to submit several messages at once.
+func (p *SubmitterPool) Submit(ctx context.Context, namespace, message []byte) (*PayForMessageResult, error) {
+	s := &submission{
+		ctx:       ctx,
+		namespace: namespace,
+		message:   message,
+		result:    make(chan submissionResult, 1),
+	}
+	if err := p.enqueue(s); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-s.result:
+		return res.result, res.err
+	case <-ctx.Done():
+		// the account stops waiting for the tx as the context is done as well
+		return nil, ctx.Err()
+	}
+}
+
+// enqueue adds the submission to the queue, waiting for room in the queue
+// until the context of the submission is done
+func (p *SubmitterPool) enqueue(s *submission) error {
+	p.closeMtx.RLock()
+	defer p.closeMtx.RUnlock()
+
+	if p.closed {
+		return ErrSubmitterPoolClosed
+	}
+	select {
+	case p.queue <- s:
+		telemetry.SetGauge(float32(len(p.queue)), ModuleName, "submitter", "queued_messages")
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// submitMessages submits the queued messages using a single account, until
+// the queue is closed
+func (p *SubmitterPool) submitMessages(client *BlobClient, account *submitterAccount) {
+	defer p.wg.Done()
+
+	for s := range p.queue {
+		if err := s.ctx.Err(); err != nil {
+			s.result <- submissionResult{err: err}
+			continue
+		}
+
+		atomic.AddInt64(&p.inFlight, 1)
+		start := time.Now()
+		result, err := client.SubmitPayForMessage(s.ctx, s.namespace, s.message, p.options...)
+		atomic.AddInt64(&p.inFlight, -1)
+
+		status := "included"
+		if err != nil {
+			status = "failed"
+			atomic.AddUint64(&account.failed, 1)
+		} else {
+			atomic.AddUint64(&account.submitted, 1)
+		}
+		labels := []metrics.Label{
+			telemetry.NewLabel("account", account.address),
+			telemetry.NewLabel("status", status),
+		}
+		telemetry.IncrCounterWithLabels([]string{ModuleName, "submitter", "messages"}, 1, labels)
+		metrics.MeasureSinceWithLabels([]string{ModuleName, "submitter", "inclusion_time"}, start, labels)
+
+		s.result <- submissionResult{result: result, err: err}
+	}
+}
+
+// SubmitterPoolStats describes the messages that were submitted by a
+// SubmitterPool
+type SubmitterPoolStats struct {
+	// Queued is the number of messages that wait for an account
+	Queued int
+	// InFlight is the number of messages that are being submitted
+	InFlight int
+	// Accounts are the stats of each account of the pool
+	Accounts []SubmitterAccountStats
+}
+
+// SubmitterAccountStats describes the messages that were submitted by an
+// account of a SubmitterPool
+type SubmitterAccountStats struct {
+	Address string
+	// Submitted is the number of messages that were included in a block
+	Submitted uint64
+	// Failed is the number of messages that were not included in a block
+	// before their context was done, or were rejected
+	Failed uint64
+}
+
+// Stats returns the current stats of the pool
+func (p *SubmitterPool) Stats() SubmitterPoolStats {
+	stats := SubmitterPoolStats{
+		Queued:   len(p.queue),
+		InFlight: int(atomic.LoadInt64(&p.inFlight)),
+		Accounts: make([]SubmitterAccountStats, len(p.accounts)),
+	}
+	for i, account := range p.accounts {
+		stats.Accounts[i] = SubmitterAccountStats{
+			Address:   account.address,
+			Submitted: atomic.LoadUint64(&account.submitted),
+			Failed:    atomic.LoadUint64(&account.failed),
+		}
+	}
+	return stats
+}
+
+// Close stops accepting messages, and waits for the queued messages to be
+// submitted
+func (p *SubmitterPool) Close() {
+	p.closeMtx.Lock()
+	if p.closed {
+		p.closeMtx.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.queue)
+	p.closeMtx.Unlock()
+
+	p.wg.Wait()
+}
+
+// CreateSubmitterKeys makes sure that the keyring contains n keys named after
+// the prefix, which are used as the accounts of a SubmitterPool, and returns
+// their names. Missing keys are created using a new mnemonic.
+func CreateSubmitterKeys(kr keyring.Keyring, prefix string, n int) ([]string, error) {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", prefix, i)
+		if _, err := kr.Key(names[i]); err == nil {
+			continue
+		}
+		_, _, err := kr.NewMnemonic(names[i], keyring.English, sdktypes.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// FundAccounts sends the amount from the account of the funder to each of the
+// accounts, using a single tx, and waits for it to be included in a block. The
+// accounts must be funded before they can pay for messages, unless their fees
+// are granted and paying for messages is free.
+func FundAccounts(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	funder *KeyringSigner,
+	accounts []sdktypes.AccAddress,
+	amount sdktypes.Coins,
+	options ...TxBuilderOption,
+) error {
+	from := funder.GetSignerInfo().GetAddress()
+	msgs := make([]sdktypes.Msg, len(accounts))
+	for i, account := range accounts {
+		msgs[i] = banktypes.NewMsgSend(from, account, amount)
+	}
+	return broadcastAndWait(ctx, conn, funder, msgs, options...)
+}
+
+// GrantFeeAllowances grants the allowance from the account of the granter to
+// each of the grantees, using a single tx, and waits for it to be included in
+// a block. The grantees can then use SetFeeGranter to pay the fees of their
+// txs with the account of the granter. The payment for the shares of a message
+// is not a fee, so it is still paid by the grantee.
+func GrantFeeAllowances(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	granter *KeyringSigner,
+	grantees []sdktypes.AccAddress,
+	allowance feegrant.FeeAllowanceI,
+	options ...TxBuilderOption,
+) error {
+	from := granter.GetSignerInfo().GetAddress()
+	msgs := make([]sdktypes.Msg, len(grantees))
+	for i, grantee := range grantees {
+		msg, err := feegrant.NewMsgGrantAllowance(allowance, from, grantee)
+		if err != nil {
+			return err
+		}
+		msgs[i] = msg
+	}
+	return broadcastAndWait(ctx, conn, granter, msgs, options...)
+}
+
+// broadcastAndWait broadcasts a tx containing the msgs, and waits for it to be
+// included in a block
+func broadcastAndWait(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	signer *KeyringSigner,
+	msgs []sdktypes.Msg,
+	options ...TxBuilderOption,
+) error {
+	if err := signer.QueryAccountNumber(ctx, conn); err != nil {
+		return err
+	}
+	resp, err := signer.BroadcastMsgs(ctx, conn, tx.BroadcastMode_BROADCAST_MODE_SYNC, msgs, options...)
+	if err != nil {
+		return err
+	}
+	txResp := resp.TxResponse
+	if txResp == nil {
+		return fmt.Errorf("no tx response")
+	}
+	if txResp.Code != 0 {
+		return sdkerrors.ABCIError(txResp.Codespace, txResp.Code, txResp.RawLog)
+	}
+
+	included, err := waitForTx(ctx, conn, DefaultInclusionPollInterval, txResp.TxHash)
+	if err != nil {
+		return err
+	}
+	if txResp = included.TxResponse; txResp.Code != 0 {
+		return sdkerrors.ABCIError(txResp.Codespace, txResp.Code, txResp.RawLog)
+	}
+	return nil
+}
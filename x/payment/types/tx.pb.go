@@ -29,8 +29,8 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
-// MsgWirePayForMessage describes the format of data that is sent over the wire for
-// each PayForMessage
+// MsgWirePayForMessage describes the format of data that is sent over the wire
+// for each PayForMessage
 type MsgWirePayForMessage struct {
 	Signer                 string                    `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
 	MessageNameSpaceId     []byte                    `protobuf:"bytes,2,opt,name=message_name_space_id,json=messageNameSpaceId,proto3" json:"message_name_space_id,omitempty"`
@@ -107,7 +107,8 @@ func (m *MsgWirePayForMessage) GetMessageShareCommitment() []ShareCommitAndSigna
 	return nil
 }
 
-// MsgWirePayForMessageResponse describes the response returned after the submission of a WirePayForMessage
+// MsgWirePayForMessageResponse describes the response returned after the
+// submission of a WirePayForMessage
 type MsgWirePayForMessageResponse struct {
 }
 
@@ -205,8 +206,12 @@ func (m *ShareCommitAndSignature) GetSignature() []byte {
 	return nil
 }
 
-// MsgPayForMessage is what gets signed by users when creating ShareCommitSignatures.
-//  Multiple versions are signed and included, each version creates a commitment for a
+// MsgPayForMessage is what gets signed by users when creating
+// ShareCommitSignatures.
+//
+//	Multiple versions are signed and included, each version creates a commitment
+//	for a
+//
 // specific square size.
 type MsgPayForMessage struct {
 	Signer                 string `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
@@ -276,7 +281,8 @@ func (m *MsgPayForMessage) GetMessageShareCommitment() []byte {
 	return nil
 }
 
-// MsgPayForMessageResponse describes the response returned after the submission of a PayForMessage
+// MsgPayForMessageResponse describes the response returned after the submission
+// of a PayForMessage
 type MsgPayForMessageResponse struct {
 }
 
@@ -324,7 +330,7 @@ func init() {
 func init() { proto.RegisterFile("payment/tx.proto", fileDescriptor_9897659aff976806) }
 
 var fileDescriptor_9897659aff976806 = []byte{
-	// 469 bytes of a gzipped FileDescriptorProto
+	// 465 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcf, 0x6b, 0x13, 0x41,
 	0x14, 0xce, 0x24, 0x21, 0xa5, 0xaf, 0x11, 0xc3, 0x10, 0xeb, 0x1a, 0xc2, 0x76, 0x93, 0x8b, 0xf1,
 	0x60, 0x56, 0xdb, 0x8b, 0x57, 0x2b, 0x08, 0x1e, 0x56, 0x64, 0x73, 0x10, 0xbc, 0xc4, 0x69, 0xf2,
@@ -353,8 +359,8 @@ var fileDescriptor_9897659aff976806 = []byte{
 	0x62, 0xd3, 0xfd, 0xe0, 0x7c, 0xe1, 0x92, 0x8b, 0x85, 0x4b, 0x7e, 0x2f, 0x5c, 0xf2, 0x75, 0xe9,
 	0xd6, 0x2e, 0x96, 0x6e, 0xed, 0xe7, 0xd2, 0xad, 0xbd, 0xdb, 0xe3, 0xc2, 0x1e, 0x9e, 0x1c, 0x8c,
 	0x67, 0x2a, 0xaa, 0x44, 0x54, 0xcc, 0xab, 0xf3, 0x63, 0xa6, 0xb5, 0x7f, 0x5a, 0xc9, 0xda, 0x44,
-	0xa3, 0x39, 0x68, 0x65, 0x3f, 0x68, 0xef, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe1, 0x2a, 0x4c,
-	0x5b, 0x92, 0x03, 0x00, 0x00,
+	0xa3, 0x39, 0x68, 0x65, 0x3f, 0x68, 0xef, 0xef, 0x00, 0xe1, 0x2a, 0x4c, 0x5b, 0x92, 0x03, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
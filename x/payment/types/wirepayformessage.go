@@ -28,13 +28,19 @@ func NewWirePayForMessage(namespace, message []byte, sizes ...uint64) (*MsgWireP
 		MessageShareCommitment: make([]ShareCommitAndSignature, len(sizes)),
 	}
 
-	// generate the share commitments
+	// generate the share commitments for every square size in a single pass
+	// over the message, and cache them for when the msg is signed and validated
+	builder := NewCommitmentBuilder(namespace, uint64(len(message)), sizes...)
+	if _, err := builder.Write(message); err != nil {
+		return nil, err
+	}
+	commits, err := builder.Commitments()
+	if err != nil {
+		return nil, err
+	}
+	commitmentCache.addCommitments(namespace, message, sizes, commits)
 	for i, size := range sizes {
-		commit, err := CreateCommitment(size, namespace, message)
-		if err != nil {
-			return nil, err
-		}
-		out.MessageShareCommitment[i] = ShareCommitAndSignature{K: size, ShareCommitment: commit}
+		out.MessageShareCommitment[i] = ShareCommitAndSignature{K: size, ShareCommitment: commits[i]}
 	}
 	return out, nil
 }
@@ -42,29 +48,56 @@ func NewWirePayForMessage(namespace, message []byte, sizes ...uint64) (*MsgWireP
 // SignShareCommitments creates and signs MsgPayForMessages for each square size configured in the MsgWirePayForMessage
 // to complete each shares commitment.
 func (msg *MsgWirePayForMessage) SignShareCommitments(signer *KeyringSigner, options ...TxBuilderOption) error {
-	msg.Signer = signer.GetSignerInfo().GetAddress().String()
-	// create an entire MsgPayForMessage and signing over it, including the signature in each commitment
-	for i, commit := range msg.MessageShareCommitment {
+	return SignWirePayForMessages(signer, []*MsgWirePayForMessage{msg}, options...)
+}
+
+// SignWirePayForMessages signs the share commitments of multiple
+// MsgWirePayForMessages that are submitted together in a single tx. The
+// malleated tx for each square size contains a MsgPayForMessage for every msg,
+// in the same order, so the signature for a square size is created over all of
+// them and included in the share commitment for that square size of every msg.
+// This requires that every msg commits to the same square sizes.
+func SignWirePayForMessages(signer *KeyringSigner, msgs []*MsgWirePayForMessage, options ...TxBuilderOption) error {
+	if len(msgs) == 0 {
+		return errors.New("no MsgWirePayForMessages to sign")
+	}
+
+	address := signer.GetSignerInfo().GetAddress().String()
+	for _, msg := range msgs {
+		msg.Signer = address
+	}
+
+	// create an entire tx of MsgPayForMessages and sign over it, including the
+	// signature in each commitment
+	for _, commit := range msgs[0].MessageShareCommitment {
 		builder := signer.NewTxBuilder()
 
 		for _, option := range options {
 			builder = option(builder)
 		}
 
-		sig, err := msg.createPayForMessageSignature(signer, builder, commit.K)
+		sig, err := createPayForMessagesSignature(signer, builder, msgs, commit.K)
 		if err != nil {
 			return err
 		}
-		msg.MessageShareCommitment[i].Signature = sig
+
+		for _, msg := range msgs {
+			for i := range msg.MessageShareCommitment {
+				if msg.MessageShareCommitment[i].K == commit.K {
+					msg.MessageShareCommitment[i].Signature = sig
+				}
+			}
+		}
 	}
 	return nil
 }
 
 func (msg *MsgWirePayForMessage) Route() string { return RouterKey }
 
-// ValidateBasic checks for valid namespace length, declared message size, share
-// commitments, signatures for those share commitments, and fulfills the sdk.Msg
-// interface
+// ValidateBasic checks for valid namespace length, declared message size, and
+// share commitments, and fulfills the sdk.Msg interface. The signatures of the
+// share commitments depend on the signer's account, so they are verified in
+// CheckTx by the payment module's ante decorator instead.
 func (msg *MsgWirePayForMessage) ValidateBasic() error {
 
 	// ensure that the namespace id is of length == NamespaceIDSize
@@ -99,14 +132,17 @@ func (msg *MsgWirePayForMessage) ValidateBasic() error {
 		return errors.New("message is not valid: uses a reserved namesapce ID")
 	}
 
-	for _, commit := range msg.MessageShareCommitment {
+	sizes := make([]uint64, len(msg.MessageShareCommitment))
+	for i, commit := range msg.MessageShareCommitment {
+		sizes[i] = commit.K
+	}
+	calculatedCommits, err := CachedCommitments(msg.GetMessageNameSpaceId(), msg.Message, sizes...)
+	if err != nil {
+		return err
+	}
+	for i, commit := range msg.MessageShareCommitment {
 		// check that each commit is valid
-		calculatedCommit, err := CreateCommitment(commit.K, msg.GetMessageNameSpaceId(), msg.Message)
-		if err != nil {
-			return err
-		}
-
-		if string(calculatedCommit) != string(commit.ShareCommitment) {
+		if string(calculatedCommits[i]) != string(commit.ShareCommitment) {
 			return fmt.Errorf("invalid commit for square size %d", commit.K)
 		}
 	}
@@ -130,14 +166,14 @@ func (msg *MsgWirePayForMessage) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{address}
 }
 
-// createPayForMessageSignature generates the signature for a PayForMessage for a single square
-// size using the info from a MsgWirePayForMessage
-func (msg *MsgWirePayForMessage) createPayForMessageSignature(signer *KeyringSigner, builder sdkclient.TxBuilder, k uint64) ([]byte, error) {
-	pfm, err := msg.unsignedPayForMessage(k)
+// createPayForMessagesSignature generates the signature for the malleated tx
+// of a single square size using the info from the MsgWirePayForMessages
+func createPayForMessagesSignature(signer *KeyringSigner, builder sdkclient.TxBuilder, msgs []*MsgWirePayForMessage, k uint64) ([]byte, error) {
+	pfms, err := malleatedMsgs(msgs, k)
 	if err != nil {
 		return nil, err
 	}
-	tx, err := signer.BuildSignedTx(builder, pfm)
+	tx, err := signer.BuildSignedTx(builder, pfms...)
 	if err != nil {
 		return nil, err
 	}
@@ -155,14 +191,26 @@ func (msg *MsgWirePayForMessage) createPayForMessageSignature(signer *KeyringSig
 	return sig.Signature, nil
 }
 
+// commitsTo checks if the msg includes a share commitment for the provided
+// square size
+func (msg *MsgWirePayForMessage) commitsTo(k uint64) bool {
+	for _, commit := range msg.MessageShareCommitment {
+		if commit.K == k {
+			return true
+		}
+	}
+	return false
+}
+
 // unsignedPayForMessage use the data in the MsgWirePayForMessage
 // to create a new MsgPayForMessage.
 func (msg *MsgWirePayForMessage) unsignedPayForMessage(k uint64) (*MsgPayForMessage, error) {
 	// create the commitment using the padded message
-	commit, err := CreateCommitment(k, msg.MessageNameSpaceId, msg.Message)
+	commits, err := CachedCommitments(msg.MessageNameSpaceId, msg.Message, k)
 	if err != nil {
 		return nil, err
 	}
+	commit := commits[0]
 
 	sPFM := MsgPayForMessage{
 		MessageNamespaceId:     msg.MessageNameSpaceId,
@@ -180,9 +228,10 @@ func ProcessWirePayForMessage(msg *MsgWirePayForMessage, squareSize uint64) (*tm
 	// make sure that a ShareCommitAndSignature of the correct size is
 	// included in the message
 	var shareCommit *ShareCommitAndSignature
-	for _, commit := range msg.MessageShareCommitment {
-		if commit.K == squareSize {
-			shareCommit = &commit
+	for i := range msg.MessageShareCommitment {
+		if msg.MessageShareCommitment[i].K == squareSize {
+			shareCommit = &msg.MessageShareCommitment[i]
+			break
 		}
 	}
 	if shareCommit == nil {
@@ -206,3 +255,29 @@ func ProcessWirePayForMessage(msg *MsgWirePayForMessage, squareSize uint64) (*tm
 
 	return &coreMsg, pfm, shareCommit.Signature, nil
 }
+
+// ProcessWirePayForMessages performs the processing required by PreProcessTxs
+// for a tx that contains multiple MsgWirePayForMessages. It returns the core
+// message and the unsigned MsgPayForMessage for each msg, in the same order,
+// along with the signature of the malleated tx for the square size. Each msg
+// must include the same signature for the square size.
+func ProcessWirePayForMessages(msgs []*MsgWirePayForMessage, squareSize uint64) ([]*tmproto.Message, []*MsgPayForMessage, []byte, error) {
+	if len(msgs) == 0 {
+		return nil, nil, nil, errors.New("no MsgWirePayForMessages to process")
+	}
+
+	coreMsgs := make([]*tmproto.Message, len(msgs))
+	pfms := make([]*MsgPayForMessage, len(msgs))
+	var sig []byte
+	for i, msg := range msgs {
+		coreMsg, pfm, msgSig, err := ProcessWirePayForMessage(msg, squareSize)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if i > 0 && !bytes.Equal(sig, msgSig) {
+			return nil, nil, nil, fmt.Errorf("message %d includes a different signature for square size %d", i, squareSize)
+		}
+		coreMsgs[i], pfms[i], sig = coreMsg, pfm, msgSig
+	}
+	return coreMsgs, pfms, sig, nil
+}